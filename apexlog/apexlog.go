@@ -0,0 +1,46 @@
+package apexlog
+
+import (
+	"github.com/apex/log"
+
+	"github.com/hyp3rd/ewrap/internal/kv"
+)
+
+// Adapter wraps an apex/log logger so it can be passed to ewrap.WithLogger.
+type Adapter struct {
+	logger log.Interface
+}
+
+// New returns an Adapter backed by logger. Any log.Interface works, so a
+// *log.Logger, the package-level log.Log, or an *log.Entry carrying
+// preset fields can all be used.
+func New(logger log.Interface) *Adapter {
+	return &Adapter{logger: logger}
+}
+
+// Error logs an error message with optional key-value pairs.
+func (a *Adapter) Error(msg string, keysAndValues ...any) {
+	a.logger.WithFields(fields(keysAndValues)).Error(msg)
+}
+
+// Debug logs a debug message with optional key-value pairs.
+func (a *Adapter) Debug(msg string, keysAndValues ...any) {
+	a.logger.WithFields(fields(keysAndValues)).Debug(msg)
+}
+
+// Info logs an info message with optional key-value pairs.
+func (a *Adapter) Info(msg string, keysAndValues ...any) {
+	a.logger.WithFields(fields(keysAndValues)).Info(msg)
+}
+
+// fields converts key-value pairs into apex Fields. Pairs whose key is not a
+// string are skipped, as is a trailing key without a value.
+func fields(keysAndValues []any) log.Fields {
+	out := make(log.Fields, len(keysAndValues)/2)
+
+	kv.Each(keysAndValues, func(key string, value any) {
+		out[key] = value
+	})
+
+	return out
+}
@@ -0,0 +1,59 @@
+package apexlog
+
+import (
+	"testing"
+
+	"github.com/apex/log"
+	"github.com/apex/log/handlers/memory"
+)
+
+func TestAdapter(t *testing.T) {
+	t.Parallel()
+
+	handler := memory.New()
+	adapter := New(&log.Logger{Handler: handler, Level: log.DebugLevel})
+
+	adapter.Error("error message", "key1", "value1")
+	adapter.Debug("debug message", "key2", "value2")
+	adapter.Info("info message", "key3", "value3")
+
+	want := []struct {
+		level log.Level
+		msg   string
+		key   string
+		value string
+	}{
+		{level: log.ErrorLevel, msg: "error message", key: "key1", value: "value1"},
+		{level: log.DebugLevel, msg: "debug message", key: "key2", value: "value2"},
+		{level: log.InfoLevel, msg: "info message", key: "key3", value: "value3"},
+	}
+
+	if len(handler.Entries) != len(want) {
+		t.Fatalf("expected %d entries, got %d", len(want), len(handler.Entries))
+	}
+
+	for i, w := range want {
+		entry := handler.Entries[i]
+		if entry.Level != w.level || entry.Message != w.msg {
+			t.Errorf("entry %d: got %v %q, want %v %q", i, entry.Level, entry.Message, w.level, w.msg)
+		}
+
+		if got := entry.Fields.Get(w.key); got != w.value {
+			t.Errorf("entry %d: field %q = %v, want %q", i, w.key, got, w.value)
+		}
+	}
+}
+
+func TestAdapterSkipsMalformedPairs(t *testing.T) {
+	t.Parallel()
+
+	handler := memory.New()
+	adapter := New(&log.Logger{Handler: handler, Level: log.DebugLevel})
+
+	adapter.Error("error message", "key", "value", 42, "orphan", "dangling")
+
+	got := handler.Entries[0].Fields
+	if len(got) != 1 || got.Get("key") != "value" {
+		t.Errorf("expected only the well-formed pair, got %v", got)
+	}
+}
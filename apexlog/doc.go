@@ -0,0 +1,4 @@
+// Package apexlog provides an adapter that lets an apex/log logger satisfy
+// the ewrap.Logger interface. It is a separate module, so apex/log is not a
+// dependency of the core ewrap module.
+package apexlog
@@ -0,0 +1,12 @@
+module github.com/hyp3rd/ewrap/apexlog
+
+go 1.26.4
+
+require (
+	github.com/apex/log v1.9.0
+	github.com/hyp3rd/ewrap v0.0.0-00010101000000-000000000000
+)
+
+require github.com/pkg/errors v0.9.1 // indirect
+
+replace github.com/hyp3rd/ewrap => ../
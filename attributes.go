@@ -1,6 +1,10 @@
 package ewrap
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"net"
+)
 
 // WithHTTPStatus tags the error with an HTTP status code. The first non-zero
 // status found while walking the chain via errors.As is what HTTPStatus
@@ -15,8 +19,7 @@ func WithHTTPStatus(status int) Option {
 // code, or 0 if none is set.
 func HTTPStatus(err error) int {
 	for err != nil {
-		var e *Error
-		if errors.As(err, &e) && e.httpStatus != 0 {
+		if e, ok := err.(*Error); ok && e.httpStatus != 0 {
 			return e.httpStatus
 		}
 
@@ -48,25 +51,115 @@ func (e *Error) Retryable() (value, set bool) {
 	return *e.retryable, true
 }
 
-// IsRetryable reports whether an error should be retried. It walks the chain
-// looking for an explicit ewrap classification first; falling back to the
-// stdlib `interface{ Temporary() bool }` (as exposed by net.Error and
-// similar) when no explicit value has been set.
+// WithTemporary overrides the answer Temporary derives from the error type.
+// Wrap inherits the override.
+func WithTemporary(temporary bool) Option {
+	return func(err *Error) {
+		err.temporary = &temporary
+	}
+}
+
+// Temporary implements the legacy net.Error-style `Temporary() bool`
+// convention, so *Error slots into retry loops written against it. Unless
+// overridden with WithTemporary, network and external errors are temporary
+// and every other type, including errors without an ErrorContext, is not.
+// IsRetryable does not consult this method on ewrap layers; it relies on
+// WithRetryable there.
+func (e *Error) Temporary() bool {
+	if e.temporary != nil {
+		return *e.temporary
+	}
+
+	switch typeOf(e) {
+	case ErrorTypeNetwork, ErrorTypeExternal:
+		return true
+	default:
+		return false
+	}
+}
+
+// Retryable is implemented by errors that know whether they are worth
+// retrying. IsRetryable and the default CanRetry predicate honor it anywhere
+// in the chain. *Error does not implement it: (*Error).Retryable also
+// reports whether the flag was set at all.
+type Retryable interface {
+	Retryable() bool
+}
+
+// IsRetryable is the single decision point for whether an error should be
+// retried. It walks the chain and, at each layer, takes the first of:
+//
+//   - an explicit ewrap classification (WithRetryable);
+//   - an ewrap layer typed ErrorTypeValidation, which is permanent;
+//   - the Retryable interface;
+//   - context.Canceled and context.DeadlineExceeded, which are permanent:
+//     the caller gave up, so retrying under the same context cannot succeed;
+//   - a net.Error reporting Timeout(), which is transient;
+//   - the stdlib `interface{ Temporary() bool }`.
+//
+// An error matching none of these is not retryable. The default ShouldRetry
+// predicate of WithRetry uses the first four signals but retries an error
+// that carries none of them.
 func IsRetryable(err error) bool {
 	for cur := err; cur != nil; cur = errors.Unwrap(cur) {
-		var e *Error
-		if errors.As(cur, &e) {
-			if v, set := e.Retryable(); set {
-				return v
-			}
+		if retryable, ok := retryableLayer(cur); ok {
+			return retryable
+		}
+	}
+
+	return false
+}
+
+// retryableLayer classifies a single chain layer for IsRetryable, reporting
+// ok=false when the layer carries no retry signal.
+func retryableLayer(err error) (retryable, ok bool) {
+	if retryable, ok := retryDecision(err); ok {
+		return retryable, true
+	}
+
+	// An ewrap layer answers Temporary from its type; only WithRetryable and
+	// the validation type count as retry signals there.
+	if _, isEwrap := err.(*Error); isEwrap {
+		return false, false
+	}
+
+	if netErr, isNet := err.(net.Error); isNet && netErr.Timeout() {
+		return true, true
+	}
+
+	if t, isTemp := err.(interface{ Temporary() bool }); isTemp {
+		return t.Temporary(), true
+	}
+
+	return false, false
+}
+
+// retryDecision reports the explicit retry signals of a single chain layer:
+// the WithRetryable flag, a validation type, the Retryable interface and a
+// cancelled or expired context. ok is false when the layer has none.
+func retryDecision(err error) (retryable, ok bool) {
+	if e, isEwrap := err.(*Error); isEwrap {
+		if v, set := e.Retryable(); set {
+			return v, true
 		}
 
-		if t, ok := cur.(interface{ Temporary() bool }); ok {
-			return t.Temporary()
+		if e.errorContext != nil && e.errorContext.Type == ErrorTypeValidation {
+			return false, true
 		}
+
+		return false, false
 	}
 
-	return false
+	if r, isRetryable := err.(Retryable); isRetryable {
+		return r.Retryable(), true
+	}
+
+	//nolint:errorlint // comparing this layer only; the callers do the walk
+	if err == context.Canceled || err == context.DeadlineExceeded {
+		return false, true
+	}
+
+	return false, false
 }
 
 // WithSafeMessage attaches a redacted variant of the error message that
@@ -94,9 +187,11 @@ func (e *Error) SafeError() string {
 		return msg
 	}
 
+	sep := currentMessageSeparator()
+
 	if c, ok := e.cause.(interface{ SafeError() string }); ok {
-		return msg + ": " + c.SafeError()
+		return msg + sep + c.SafeError()
 	}
 
-	return msg + ": " + e.cause.Error()
+	return msg + sep + e.cause.Error()
 }
@@ -1,7 +1,10 @@
 package ewrap
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"testing"
 )
@@ -97,6 +100,48 @@ func TestIsRetryable(t *testing.T) {
 	})
 }
 
+func TestIsRetryableStdlibCases(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"net.Error timeout", &net.OpError{Op: "dial", Err: timeoutError{}}, true},
+		{"wrapped net.Error timeout", Wrap(&net.DNSError{IsTimeout: true}, "resolving"), true},
+		{"context canceled", Wrap(context.Canceled, "querying"), false},
+		{"context deadline", fmt.Errorf("querying: %w", context.DeadlineExceeded), false},
+		{"Retryable true", Wrap(retryableError(true), "calling"), true},
+		{"Retryable false", retryableError(false), false},
+		{"explicit beats Retryable", Wrap(retryableError(true), "calling", WithRetryable(false)), false},
+		{"validation layer", Wrap(timeoutError{}, "parsing", WithType(ErrorTypeValidation)), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// timeoutError is a net.Error-style cause reporting a timeout.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return false }
+
+// retryableError implements the Retryable interface.
+type retryableError bool
+
+func (retryableError) Error() string     { return "retryable" }
+func (r retryableError) Retryable() bool { return bool(r) }
+
 type temporaryError struct {
 	msg  string
 	temp bool
@@ -176,3 +221,38 @@ func errorStartsWithMessage(formatted, message string) bool {
 
 	return formatted[:len(message)] == message
 }
+
+func TestTemporary(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		err  *Error
+		want bool
+	}{
+		{name: "network", err: New(msgPlain, WithType(ErrorTypeNetwork)), want: true},
+		{name: "external", err: New(msgPlain, WithType(ErrorTypeExternal)), want: true},
+		{name: "validation", err: New(msgPlain, WithType(ErrorTypeValidation)), want: false},
+		{name: "permission", err: New(msgPlain, WithType(ErrorTypePermission)), want: false},
+		{name: "no context", err: New(msgPlain), want: false},
+		{name: "type from inner layer", err: Wrap(New(msgPlain, WithType(ErrorTypeNetwork)), msgWrapped), want: true},
+		{name: "override true", err: New(msgPlain, WithType(ErrorTypeValidation), WithTemporary(true)), want: true},
+		{name: "override false", err: New(msgPlain, WithType(ErrorTypeNetwork), WithTemporary(false)), want: false},
+		{name: "override inherited", err: Wrap(New(msgPlain, WithTemporary(true)), msgWrapped), want: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.err.Temporary(); got != tc.want {
+				t.Errorf("Temporary() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	var legacy interface{ Temporary() bool }
+	if !errors.As(error(New(msgPlain, WithType(ErrorTypeNetwork))), &legacy) || !legacy.Temporary() {
+		t.Error("*Error should satisfy the legacy Temporary interface")
+	}
+}
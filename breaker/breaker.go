@@ -14,8 +14,10 @@ type Breaker struct {
 	lastFailure   time.Time
 	state         State
 	observer      Observer
+	clock         Clock
 	mu            sync.Mutex
 	onStateChange func(name string, from, to State)
+	listeners     []func(name string, from, to State)
 }
 
 // State represents the breaker's operational state.
@@ -52,6 +54,19 @@ type Observer interface {
 	RecordTransition(name string, from, to State)
 }
 
+// Clock is the breaker's time source. Replace the real clock with a fake one
+// via SetClock to drive the open to half-open transition in tests without
+// sleeping.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// realClock is the default Clock, backed by time.Now.
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
 type noopObserver struct{}
 
 func (noopObserver) RecordTransition(string, State, State) {}
@@ -59,14 +74,16 @@ func (noopObserver) RecordTransition(string, State, State) {}
 // transitionEvent captures a state change so observer/callback dispatch can
 // happen outside the breaker lock.
 type transitionEvent struct {
-	name     string
-	from, to State
-	observer Observer
-	callback func(string, State, State)
+	name      string
+	from, to  State
+	observer  Observer
+	callback  func(string, State, State)
+	listeners []func(string, State, State)
 }
 
 // New creates a Breaker named name that opens after maxFailures consecutive
-// failures and probes recovery after timeout has elapsed in the open state.
+// failures (or an equivalent weighted sum, see RecordFailureWeighted) and
+// probes recovery after timeout has elapsed in the open state.
 func New(name string, maxFailures int, timeout time.Duration) *Breaker {
 	return NewWithObserver(name, maxFailures, timeout, nil)
 }
@@ -84,6 +101,7 @@ func NewWithObserver(name string, maxFailures int, timeout time.Duration, observ
 		timeout:     timeout,
 		state:       Closed,
 		observer:    observer,
+		clock:       realClock{},
 	}
 }
 
@@ -101,15 +119,34 @@ func (cb *Breaker) State() State {
 	return cb.state
 }
 
-// OnStateChange installs a callback fired after each state transition. The
-// callback runs synchronously outside the breaker lock and must not invoke
-// the breaker recursively.
+// OnStateChange installs a callback fired after each state transition,
+// replacing any callback installed by an earlier call; use
+// AddStateChangeListener to register several. The callback runs
+// synchronously outside the breaker lock, after the observer, and has
+// returned by the time the triggering call does, so ordering is
+// deterministic. It must not invoke the breaker recursively.
 func (cb *Breaker) OnStateChange(callback func(name string, from, to State)) {
 	cb.mu.Lock()
 	cb.onStateChange = callback
 	cb.mu.Unlock()
 }
 
+// AddStateChangeListener registers an additional callback fired after each
+// state transition. Unlike OnStateChange, which holds a single callback and
+// replaces it, listeners accumulate, so independent subscribers (logging,
+// metrics) can each react. Listeners run synchronously after the observer and
+// the OnStateChange callback, in registration order, under the same rules:
+// outside the breaker lock, and never invoking the breaker recursively.
+func (cb *Breaker) AddStateChangeListener(listener func(name string, from, to State)) {
+	if listener == nil {
+		return
+	}
+
+	cb.mu.Lock()
+	cb.listeners = append(cb.listeners, listener)
+	cb.mu.Unlock()
+}
+
 // SetObserver replaces the observer. A nil value is replaced with a no-op
 // implementation so callers never need to nil-check before recording.
 func (cb *Breaker) SetObserver(observer Observer) {
@@ -122,11 +159,34 @@ func (cb *Breaker) SetObserver(observer Observer) {
 	cb.mu.Unlock()
 }
 
-// RecordFailure records a failure and potentially opens the breaker.
+// SetClock replaces the time source used to timestamp failures and to decide
+// when the open timeout has elapsed. A nil value restores the real clock.
+func (cb *Breaker) SetClock(clock Clock) {
+	if clock == nil {
+		clock = realClock{}
+	}
+
+	cb.mu.Lock()
+	cb.clock = clock
+	cb.mu.Unlock()
+}
+
+// RecordFailure records a failure and potentially opens the breaker. It is
+// equivalent to RecordFailureWeighted(1).
 func (cb *Breaker) RecordFailure() {
+	cb.RecordFailureWeighted(1)
+}
+
+// RecordFailureWeighted records a failure that counts weight times toward
+// maxFailures, so severe failures (a refused connection, say) can trip the
+// breaker sooner than mild ones (a timeout). The breaker opens once the
+// weighted sum reaches maxFailures. Weights below 1 count as 1.
+func (cb *Breaker) RecordFailureWeighted(weight int) {
+	weight = max(weight, 1)
+
 	cb.mu.Lock()
-	cb.failureCount++
-	cb.lastFailure = time.Now()
+	cb.failureCount += weight
+	cb.lastFailure = cb.clock.Now()
 
 	var event *transitionEvent
 	if cb.state == Closed && cb.failureCount >= cb.maxFailures {
@@ -170,7 +230,7 @@ func (cb *Breaker) CanExecute() bool {
 	case Closed, HalfOpen:
 		can = true
 	case Open:
-		if time.Since(cb.lastFailure) > cb.timeout {
+		if cb.clock.Now().Sub(cb.lastFailure) > cb.timeout {
 			event = cb.setStateLocked(HalfOpen)
 			can = true
 		}
@@ -197,11 +257,12 @@ func (cb *Breaker) setStateLocked(newState State) *transitionEvent {
 	cb.state = newState
 
 	return &transitionEvent{
-		name:     cb.name,
-		from:     oldState,
-		to:       newState,
-		observer: cb.observer,
-		callback: cb.onStateChange,
+		name:      cb.name,
+		from:      oldState,
+		to:        newState,
+		observer:  cb.observer,
+		callback:  cb.onStateChange,
+		listeners: cb.listeners,
 	}
 }
 
@@ -219,4 +280,8 @@ func (*Breaker) fireTransition(event *transitionEvent) {
 	if event.callback != nil {
 		event.callback(event.name, event.from, event.to)
 	}
+
+	for _, listener := range event.listeners {
+		listener(event.name, event.from, event.to)
+	}
 }
@@ -123,11 +123,36 @@ func TestRecordSuccess(t *testing.T) {
 	}
 }
 
+// fakeClock is a Clock that only moves when advanced.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
 func TestCanExecute(t *testing.T) {
 	t.Parallel()
 
-	timeout := testConcurrencyLimit * time.Millisecond
+	timeout := testTimeoutSeconds * time.Second
+	clock := newFakeClock()
 	cb := New(testName, 1, timeout)
+	cb.SetClock(clock)
 
 	if !cb.CanExecute() {
 		t.Error("expected CanExecute true for closed breaker")
@@ -139,7 +164,13 @@ func TestCanExecute(t *testing.T) {
 		t.Error("expected CanExecute false for open breaker")
 	}
 
-	time.Sleep(timeout + 10*time.Millisecond)
+	clock.Advance(timeout)
+
+	if cb.CanExecute() {
+		t.Error("expected CanExecute false until the timeout has passed")
+	}
+
+	clock.Advance(time.Nanosecond)
 
 	if !cb.CanExecute() {
 		t.Error("expected CanExecute true after timeout (half-open)")
@@ -199,6 +230,45 @@ func TestOnStateChange(t *testing.T) {
 	}
 }
 
+// TestOnStateChangeRunsInline pins the synchronous dispatch contract: the
+// observer and callback have both run, in that order, by the time the
+// triggering call returns, so no sleeps are needed to observe them.
+func TestOnStateChangeRunsInline(t *testing.T) {
+	t.Parallel()
+
+	var events []string
+
+	cb := NewWithObserver(testName, 1, 0, observerFunc(func(_ string, _, to State) {
+		events = append(events, "observer:"+to.String())
+	}))
+
+	cb.OnStateChange(func(_ string, _, to State) {
+		events = append(events, "callback:"+to.String())
+	})
+
+	cb.RecordFailure()
+
+	events = append(events, "returned")
+
+	want := []string{"observer:open", "callback:open", "returned"}
+	if len(events) != len(want) {
+		t.Fatalf("events: got %v, want %v", events, want)
+	}
+
+	for i := range want {
+		if events[i] != want[i] {
+			t.Fatalf("events: got %v, want %v", events, want)
+		}
+	}
+}
+
+// observerFunc adapts a function to the Observer interface.
+type observerFunc func(name string, from, to State)
+
+func (f observerFunc) RecordTransition(name string, from, to State) {
+	f(name, from, to)
+}
+
 func TestTransitionViaPublicAPI(t *testing.T) {
 	t.Parallel()
 
@@ -272,11 +342,13 @@ func TestObserverViaConstructor(t *testing.T) {
 
 	obs := &recordingObserver{}
 
-	timeout := 10 * time.Millisecond
+	timeout := testTimeoutSeconds * time.Second
+	clock := newFakeClock()
 	cb := NewWithObserver(testName, 1, timeout, obs)
+	cb.SetClock(clock)
 
 	cb.RecordFailure()
-	time.Sleep(timeout + time.Millisecond)
+	clock.Advance(timeout + time.Second)
 
 	if !cb.CanExecute() {
 		t.Fatal("expected breaker to allow execution after timeout")
@@ -330,3 +402,92 @@ func TestNoObserverIsSafe(t *testing.T) {
 	cb := New(testName, 1, 10*time.Millisecond)
 	cb.RecordFailure() // Must not panic with default no-op observer
 }
+
+func TestAddStateChangeListener(t *testing.T) {
+	t.Parallel()
+
+	cb := New(testName, 1, testTimeoutSeconds*time.Second)
+
+	var events []string
+
+	cb.OnStateChange(func(_ string, _, to State) {
+		events = append(events, "callback:"+to.String())
+	})
+	cb.AddStateChangeListener(func(_ string, _, to State) {
+		events = append(events, "logging:"+to.String())
+	})
+	cb.AddStateChangeListener(func(_ string, _, to State) {
+		events = append(events, "metrics:"+to.String())
+	})
+	cb.AddStateChangeListener(nil) // ignored
+
+	cb.RecordFailure()
+
+	want := []string{"callback:open", "logging:open", "metrics:open"}
+	if len(events) != len(want) {
+		t.Fatalf("events: got %v, want %v", events, want)
+	}
+
+	for i := range want {
+		if events[i] != want[i] {
+			t.Fatalf("events: got %v, want %v", events, want)
+		}
+	}
+}
+
+func TestRecordFailureWeighted(t *testing.T) {
+	t.Parallel()
+
+	cb := New(testName, testMaxFailures, testTimeoutSeconds*time.Second)
+
+	cb.RecordFailureWeighted(testMaxFailures)
+
+	if cb.State() != Open {
+		t.Errorf("a single weight-%d failure should trip maxFailures=%d, got %v",
+			testMaxFailures, testMaxFailures, cb.State())
+	}
+
+	mixed := New(testName, testMaxFailures, testTimeoutSeconds*time.Second)
+
+	mixed.RecordFailureWeighted(2)
+	mixed.RecordFailureWeighted(0) // counts as 1
+
+	if mixed.State() != Open {
+		t.Errorf("weighted sum reaching maxFailures should trip, got %v (count %d)",
+			mixed.State(), mixed.failureCount)
+	}
+
+	light := New(testName, testMaxFailures, testTimeoutSeconds*time.Second)
+
+	light.RecordFailureWeighted(testMaxFailures - 1)
+
+	if light.State() != Closed {
+		t.Errorf("weighted sum below maxFailures must not trip, got %v", light.State())
+	}
+}
+
+func TestSetClock(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock()
+	cb := New(testName, 1, time.Minute)
+	cb.SetClock(clock)
+
+	cb.RecordFailure()
+
+	if cb.CanExecute() {
+		t.Fatal("expected the breaker to stay open while the fake clock is still")
+	}
+
+	clock.Advance(time.Minute + time.Second)
+
+	if !cb.CanExecute() || cb.State() != HalfOpen {
+		t.Fatal("expected the half-open transition once the fake clock passes the timeout")
+	}
+
+	cb.SetClock(nil)
+
+	if _, ok := cb.clock.(realClock); !ok {
+		t.Errorf("SetClock(nil) should restore the real clock, got %T", cb.clock)
+	}
+}
@@ -0,0 +1,83 @@
+package breaker
+
+import (
+	"slices"
+	"sync"
+)
+
+// Registry tracks a set of breakers by name so their health can be queried
+// together, e.g. from a readiness probe. The zero value is not usable; call
+// NewRegistry.
+type Registry struct {
+	mu       sync.RWMutex
+	breakers map[string]*Breaker
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{breakers: make(map[string]*Breaker)}
+}
+
+// Register adds cb under its Name, replacing any breaker already registered
+// under that name. A nil breaker is ignored.
+func (r *Registry) Register(cb *Breaker) {
+	if cb == nil {
+		return
+	}
+
+	r.mu.Lock()
+	r.breakers[cb.Name()] = cb
+	r.mu.Unlock()
+}
+
+// Get returns the breaker registered under name.
+func (r *Registry) Get(name string) (*Breaker, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cb, ok := r.breakers[name]
+
+	return cb, ok
+}
+
+// Healthy reports whether no registered breaker is Open. Half-open breakers
+// count as healthy since they are already letting traffic through.
+func (r *Registry) Healthy() bool {
+	for _, cb := range r.snapshot() {
+		if cb.State() == Open {
+			return false
+		}
+	}
+
+	return true
+}
+
+// OpenBreakers returns the names of the registered breakers that are Open,
+// sorted alphabetically, or nil when all are healthy.
+func (r *Registry) OpenBreakers() []string {
+	var open []string
+
+	for _, cb := range r.snapshot() {
+		if cb.State() == Open {
+			open = append(open, cb.Name())
+		}
+	}
+
+	slices.Sort(open)
+
+	return open
+}
+
+// snapshot returns the registered breakers so their states can be read
+// without holding the registry lock.
+func (r *Registry) snapshot() []*Breaker {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	breakers := make([]*Breaker, 0, len(r.breakers))
+	for _, cb := range r.breakers {
+		breakers = append(breakers, cb)
+	}
+
+	return breakers
+}
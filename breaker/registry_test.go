@@ -0,0 +1,40 @@
+package breaker
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRegistryHealth(t *testing.T) {
+	t.Parallel()
+
+	registry := NewRegistry()
+
+	payments := New("payments", 1, testTimeoutSeconds*time.Second)
+	search := New("search", 1, testTimeoutSeconds*time.Second)
+	auth := New("auth", 1, testTimeoutSeconds*time.Second)
+
+	for _, cb := range []*Breaker{payments, search, auth, nil} {
+		registry.Register(cb)
+	}
+
+	if !registry.Healthy() || registry.OpenBreakers() != nil {
+		t.Fatal("a registry of closed breakers should be healthy")
+	}
+
+	search.RecordFailure()
+	payments.RecordFailure()
+
+	if registry.Healthy() {
+		t.Error("expected unhealthy with open breakers")
+	}
+
+	if got, want := registry.OpenBreakers(), []string{"payments", "search"}; !slices.Equal(got, want) {
+		t.Errorf("OpenBreakers() = %v, want %v", got, want)
+	}
+
+	if cb, ok := registry.Get("auth"); !ok || cb != auth {
+		t.Error("Get should return the registered breaker")
+	}
+}
@@ -0,0 +1,95 @@
+package ewrap
+
+import "sync"
+
+// Canonical string forms for Category.
+const (
+	categoryUnknownStr        = "unknown"
+	categoryClientStr         = "client"
+	categoryInfrastructureStr = "infrastructure"
+	categoryInternalStr       = "internal"
+)
+
+// Category groups ErrorTypes into a smaller set of buckets for dashboards,
+// alert routing, and other roll-ups that don't need the full type detail.
+type Category int
+
+const (
+	// CategoryUnknown is reported for types with no known category.
+	CategoryUnknown Category = iota
+	// CategoryClient covers errors caused by the caller: validation,
+	// not-found, and permission failures.
+	CategoryClient
+	// CategoryInfrastructure covers failures of systems the service depends
+	// on: databases, networks, and external services.
+	CategoryInfrastructure
+	// CategoryInternal covers faults in the service itself: internal errors
+	// and misconfiguration.
+	CategoryInternal
+)
+
+// String returns the string representation of the category.
+func (c Category) String() string {
+	switch c {
+	case CategoryClient:
+		return categoryClientStr
+	case CategoryInfrastructure:
+		return categoryInfrastructureStr
+	case CategoryInternal:
+		return categoryInternalStr
+	case CategoryUnknown:
+		fallthrough
+	default:
+		return categoryUnknownStr
+	}
+}
+
+// categoryRegistry holds custom ErrorType to Category mappings. Entries take
+// precedence over the built-in mapping in Category.
+//
+//nolint:gochecknoglobals // package-wide registry guarded by its own lock
+var categoryRegistry = struct {
+	mu         sync.RWMutex
+	categories map[ErrorType]Category
+}{
+	categories: make(map[ErrorType]Category),
+}
+
+// RegisterCategory maps t to c, overriding the built-in mapping for t if it
+// has one. Use it to categorize custom ErrorType values or to re-bucket the
+// built-in ones.
+//
+// The registry is goroutine-safe but global; register mappings during
+// program initialization.
+func RegisterCategory(t ErrorType, c Category) {
+	categoryRegistry.mu.Lock()
+	defer categoryRegistry.mu.Unlock()
+
+	categoryRegistry.categories[t] = c
+}
+
+// Category returns the category et belongs to: the one registered via
+// RegisterCategory if any, otherwise the built-in mapping. Unmapped types
+// report CategoryUnknown.
+func (et ErrorType) Category() Category {
+	categoryRegistry.mu.RLock()
+	c, ok := categoryRegistry.categories[et]
+	categoryRegistry.mu.RUnlock()
+
+	if ok {
+		return c
+	}
+
+	switch et {
+	case ErrorTypeValidation, ErrorTypeNotFound, ErrorTypePermission:
+		return CategoryClient
+	case ErrorTypeDatabase, ErrorTypeNetwork, ErrorTypeExternal:
+		return CategoryInfrastructure
+	case ErrorTypeConfiguration, ErrorTypeInternal:
+		return CategoryInternal
+	case ErrorTypeUnknown:
+		fallthrough
+	default:
+		return CategoryUnknown
+	}
+}
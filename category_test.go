@@ -0,0 +1,64 @@
+package ewrap
+
+import "testing"
+
+func TestErrorTypeCategory(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		errType ErrorType
+		want    Category
+	}{
+		{ErrorTypeUnknown, CategoryUnknown},
+		{ErrorTypeValidation, CategoryClient},
+		{ErrorTypeNotFound, CategoryClient},
+		{ErrorTypePermission, CategoryClient},
+		{ErrorTypeDatabase, CategoryInfrastructure},
+		{ErrorTypeNetwork, CategoryInfrastructure},
+		{ErrorTypeExternal, CategoryInfrastructure},
+		{ErrorTypeConfiguration, CategoryInternal},
+		{ErrorTypeInternal, CategoryInternal},
+		{ErrorType(-1), CategoryUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := tt.errType.Category(); got != tt.want {
+			t.Errorf("%v.Category(): got %v, want %v", tt.errType, got, tt.want)
+		}
+	}
+}
+
+func TestCategoryString(t *testing.T) {
+	t.Parallel()
+
+	tests := map[Category]string{
+		CategoryUnknown:        "unknown",
+		CategoryClient:         "client",
+		CategoryInfrastructure: "infrastructure",
+		CategoryInternal:       "internal",
+		Category(-1):           "unknown",
+	}
+
+	for c, want := range tests {
+		if got := c.String(); got != want {
+			t.Errorf("Category(%d).String(): got %q, want %q", int(c), got, want)
+		}
+	}
+}
+
+func TestRegisterCategory(t *testing.T) {
+	t.Parallel()
+
+	const errorTypeBilling ErrorType = 1000
+
+	if got := errorTypeBilling.Category(); got != CategoryUnknown {
+		t.Fatalf("unregistered custom type: got %v, want %v", got, CategoryUnknown)
+	}
+
+	RegisterCategory(errorTypeBilling, CategoryInfrastructure)
+	t.Cleanup(func() { RegisterCategory(errorTypeBilling, CategoryUnknown) })
+
+	if got := errorTypeBilling.Category(); got != CategoryInfrastructure {
+		t.Errorf("registered custom type: got %v, want %v", got, CategoryInfrastructure)
+	}
+}
@@ -0,0 +1,34 @@
+package charmlog
+
+import (
+	"github.com/charmbracelet/log"
+
+	"github.com/hyp3rd/ewrap/internal/kv"
+)
+
+// Adapter wraps a charmbracelet/log *log.Logger so it can be passed to
+// ewrap.WithLogger. Malformed pairs are dropped rather than logged with
+// charm's "missing value" placeholder.
+type Adapter struct {
+	logger *log.Logger
+}
+
+// New returns an Adapter backed by logger.
+func New(logger *log.Logger) *Adapter {
+	return &Adapter{logger: logger}
+}
+
+// Error logs an error message with optional key-value pairs.
+func (a *Adapter) Error(msg string, keysAndValues ...any) {
+	a.logger.Error(msg, kv.Pairs(keysAndValues)...)
+}
+
+// Debug logs a debug message with optional key-value pairs.
+func (a *Adapter) Debug(msg string, keysAndValues ...any) {
+	a.logger.Debug(msg, kv.Pairs(keysAndValues)...)
+}
+
+// Info logs an info message with optional key-value pairs.
+func (a *Adapter) Info(msg string, keysAndValues ...any) {
+	a.logger.Info(msg, kv.Pairs(keysAndValues)...)
+}
@@ -0,0 +1,60 @@
+package charmlog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/log"
+)
+
+func newBufferedAdapter() (*Adapter, *bytes.Buffer) {
+	var buf bytes.Buffer
+
+	logger := log.NewWithOptions(&buf, log.Options{Level: log.DebugLevel, Formatter: log.LogfmtFormatter})
+
+	return New(logger), &buf
+}
+
+func TestAdapter(t *testing.T) {
+	t.Parallel()
+
+	adapter, buf := newBufferedAdapter()
+
+	cases := []struct {
+		want string
+		emit func()
+	}{
+		{want: "level=error msg=\"error message\" key1=value1", emit: func() { adapter.Error("error message", "key1", "value1") }},
+		{want: "level=debug msg=\"debug message\" key2=value2", emit: func() { adapter.Debug("debug message", "key2", "value2") }},
+		{want: "level=info msg=\"info message\" key3=value3", emit: func() { adapter.Info("info message", "key3", "value3") }},
+	}
+
+	for _, tc := range cases {
+		buf.Reset()
+		tc.emit()
+
+		if out := buf.String(); !strings.Contains(out, tc.want) {
+			t.Errorf("expected %q in output, got %q", tc.want, out)
+		}
+	}
+}
+
+func TestAdapterDropsMalformedPairs(t *testing.T) {
+	t.Parallel()
+
+	adapter, buf := newBufferedAdapter()
+
+	adapter.Error("error message", "key", "value", 42, "orphan", "dangling")
+
+	out := buf.String()
+	if !strings.Contains(out, "key=value") {
+		t.Errorf("expected the well-formed pair, got %q", out)
+	}
+
+	for _, unwanted := range []string{"dangling", "orphan", "missing value"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("malformed pair leaked %q into output: %q", unwanted, out)
+		}
+	}
+}
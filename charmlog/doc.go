@@ -0,0 +1,4 @@
+// Package charmlog provides an adapter that lets a charmbracelet/log logger
+// satisfy the ewrap.Logger interface. It is a separate module, so
+// charmbracelet/log is not a dependency of the core ewrap module.
+package charmlog
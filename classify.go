@@ -0,0 +1,133 @@
+package ewrap
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net"
+	"slices"
+	"sync"
+)
+
+// Classifier infers the type and severity of a standard error. It reports
+// false when it doesn't recognize err.
+type Classifier func(err error) (ErrorType, Severity, bool)
+
+// classifierRegistry holds the classifiers consulted by Wrap, in
+// registration order.
+//
+//nolint:gochecknoglobals // package-wide registry guarded by its own lock
+var classifierRegistry struct {
+	mu          sync.RWMutex
+	classifiers []*Classifier
+}
+
+// RegisterClassifier adds fn to the classifiers Wrap and Newf consult when
+// they wrap an error with no *Error in its chain. The first classifier that
+// recognizes the error wins, and the wrapper gets an ErrorContext with the
+// inferred type and severity. Wrap options such as WithContext are applied
+// afterwards and take precedence. For example, to treat sql.ErrNoRows as
+// not-found:
+//
+//	ewrap.RegisterClassifier(func(err error) (ewrap.ErrorType, ewrap.Severity, bool) {
+//		if errors.Is(err, sql.ErrNoRows) {
+//			return ewrap.ErrorTypeNotFound, ewrap.SeverityWarning, true
+//		}
+//
+//		return ewrap.ErrorTypeUnknown, ewrap.SeverityError, false
+//	})
+//
+// Registered classifiers run before the built-in ones (see
+// DefaultClassifier), so they can override them. The returned function
+// removes fn again; calling it more than once is harmless.
+//
+// The registry is goroutine-safe but global; register classifiers during
+// program initialization. A nil fn is ignored.
+func RegisterClassifier(fn Classifier) (unregister func()) {
+	if fn == nil {
+		return func() {}
+	}
+
+	entry := &fn
+
+	classifierRegistry.mu.Lock()
+	defer classifierRegistry.mu.Unlock()
+
+	classifierRegistry.classifiers = append(classifierRegistry.classifiers, entry)
+
+	return func() {
+		classifierRegistry.mu.Lock()
+		defer classifierRegistry.mu.Unlock()
+
+		classifierRegistry.classifiers = slices.DeleteFunc(classifierRegistry.classifiers,
+			func(c *Classifier) bool { return c == entry })
+	}
+}
+
+// DefaultClassifier is the built-in classifier consulted after the
+// registered ones. It recognizes standard library errors:
+//
+//   - context.DeadlineExceeded: ErrorTypeExternal, SeverityWarning
+//   - context.Canceled: ErrorTypeUnknown, SeverityInfo
+//   - fs.ErrNotExist: ErrorTypeNotFound, SeverityError
+//   - fs.ErrPermission: ErrorTypePermission, SeverityError
+//   - any net.Error: ErrorTypeNetwork, SeverityError
+func DefaultClassifier(err error) (ErrorType, Severity, bool) {
+	var netErr net.Error
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorTypeExternal, SeverityWarning, true
+	case errors.Is(err, context.Canceled):
+		return ErrorTypeUnknown, SeverityInfo, true
+	case errors.Is(err, fs.ErrNotExist):
+		return ErrorTypeNotFound, SeverityError, true
+	case errors.Is(err, fs.ErrPermission):
+		return ErrorTypePermission, SeverityError, true
+	case errors.As(err, &netErr):
+		return ErrorTypeNetwork, SeverityError, true
+	default:
+		return ErrorTypeUnknown, SeverityError, false
+	}
+}
+
+// classify runs the registered classifiers, then DefaultClassifier, over err
+// and returns the first match.
+func classify(err error) (ErrorType, Severity, bool) {
+	classifierRegistry.mu.RLock()
+	defer classifierRegistry.mu.RUnlock()
+
+	for _, fn := range classifierRegistry.classifiers {
+		if errorType, severity, ok := (*fn)(err); ok {
+			return errorType, severity, true
+		}
+	}
+
+	return DefaultClassifier(err)
+}
+
+// withInferredContext attaches an ErrorContext built from the first
+// classifier that recognizes the wrapped standard error, if any, and returns
+// it.
+func (e *Error) withInferredContext() *ErrorContext {
+	errorType, severity, ok := classify(e.cause)
+	if !ok {
+		return nil
+	}
+
+	e.errorContext = newErrorContext(context.Background(), errorType, severity, e.callerSkip)
+	e.ownsContext = true
+
+	return e.errorContext
+}
+
+// locateInferredContext re-records the File/Line of an inferred context once
+// the options have run, so a WithCallerSkip among them is honored. A context
+// that an option replaced, such as with WithContext, is left alone.
+func (e *Error) locateInferredContext(inferred *ErrorContext) {
+	if inferred == nil || e.errorContext != inferred || e.callerSkip == 0 {
+		return
+	}
+
+	inferred.File, inferred.Line = callerLocation(e.callerSkip)
+}
@@ -0,0 +1,134 @@
+package ewrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"runtime"
+	"testing"
+)
+
+// quotaError is a standard error type recognized by the test classifier.
+type quotaError struct{}
+
+func (quotaError) Error() string { return "quota exceeded" }
+
+// classifyQuota recognizes quotaError as an external warning.
+func classifyQuota(err error) (ErrorType, Severity, bool) {
+	if errors.As(err, new(quotaError)) {
+		return ErrorTypeExternal, SeverityWarning, true
+	}
+
+	return ErrorTypeUnknown, SeverityError, false
+}
+
+// wrapFromHelper wraps err the way a thin helper would, hiding itself from
+// the recorded location.
+func wrapFromHelper(err error) *Error {
+	return Wrap(err, msgWrapped, WithCallerSkip(1))
+}
+
+//nolint:paralleltest // mutates the global classifier registry
+func TestRegisterClassifier(t *testing.T) {
+	RegisterClassifier(nil)() // ignored
+
+	t.Cleanup(RegisterClassifier(classifyQuota))
+
+	wrapped := Wrap(fmt.Errorf("calling billing: %w", quotaError{}), msgWrapped)
+
+	if !wrapped.IsType(ErrorTypeExternal) {
+		t.Errorf("expected the inferred type %v, got %v", ErrorTypeExternal, wrapped.GetErrorContext().Type)
+	}
+
+	if got := wrapped.SeverityLevel(); got != SeverityWarning {
+		t.Errorf("severity: got %v, want %v", got, SeverityWarning)
+	}
+
+	// Explicit options take precedence over the inferred classification.
+	explicit := Wrap(quotaError{}, msgWrapped, WithContext(t.Context(), ErrorTypeInternal, SeverityCritical))
+	if !explicit.IsType(ErrorTypeInternal) || explicit.SeverityLevel() != SeverityCritical {
+		t.Errorf("expected WithContext to win, got %v/%v", explicit.GetErrorContext().Type, explicit.SeverityLevel())
+	}
+
+	// Newf classifies its %w cause like Wrap does.
+	if formatted := Newf("billing: %w", quotaError{}); !formatted.IsType(ErrorTypeExternal) {
+		t.Errorf("expected Newf to infer %v, got %+v", ErrorTypeExternal, formatted.GetErrorContext())
+	}
+
+	// Unrecognized errors keep the defaults.
+	if plain := Wrap(errPlain, msgWrapped); plain.GetErrorContext() != nil {
+		t.Errorf("expected no inferred context, got %+v", plain.GetErrorContext())
+	}
+}
+
+//nolint:paralleltest // mutates the global classifier registry
+func TestUnregisterClassifier(t *testing.T) {
+	unregister := RegisterClassifier(classifyQuota)
+	unregister()
+	unregister() // harmless
+
+	if ctx := Wrap(quotaError{}, msgWrapped).GetErrorContext(); ctx != nil {
+		t.Errorf("expected no classification after unregistering, got %+v", ctx)
+	}
+}
+
+//nolint:paralleltest // mutates the global classifier registry
+func TestRegisteredClassifierOverridesDefaults(t *testing.T) {
+	t.Cleanup(RegisterClassifier(func(err error) (ErrorType, Severity, bool) {
+		if errors.Is(err, fs.ErrNotExist) {
+			return ErrorTypeConfiguration, SeverityCritical, true
+		}
+
+		return ErrorTypeUnknown, SeverityError, false
+	}))
+
+	if wrapped := Wrap(fs.ErrNotExist, msgWrapped); !wrapped.IsType(ErrorTypeConfiguration) {
+		t.Errorf("expected the registered classifier to win, got %+v", wrapped.GetErrorContext())
+	}
+}
+
+func TestDefaultClassifier(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		wantType ErrorType
+		severity Severity
+	}{
+		{"deadline", context.DeadlineExceeded, ErrorTypeExternal, SeverityWarning},
+		{"canceled", context.Canceled, ErrorTypeUnknown, SeverityInfo},
+		{"not exist", fmt.Errorf("open config: %w", fs.ErrNotExist), ErrorTypeNotFound, SeverityError},
+		{"permission", fs.ErrPermission, ErrorTypePermission, SeverityError},
+		{"net", timeoutError{}, ErrorTypeNetwork, SeverityError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := Wrap(tt.err, msgWrapped).GetErrorContext()
+			if ctx == nil || ctx.Type != tt.wantType || ctx.Severity != tt.severity {
+				t.Errorf("got %+v, want %v/%v", ctx, tt.wantType, tt.severity)
+			}
+		})
+	}
+
+	if _, _, ok := DefaultClassifier(errPlain); ok {
+		t.Error("an unrelated error must not be classified")
+	}
+}
+
+func TestInferredContextHonorsCallerSkip(t *testing.T) {
+	t.Parallel()
+
+	err := wrapFromHelper(context.DeadlineExceeded)
+	_, file, line, _ := runtime.Caller(0)
+	line-- // the call to wrapFromHelper is on the previous line
+
+	ec := err.GetErrorContext()
+	if ec == nil || ec.File != file || ec.Line != line {
+		t.Errorf("ErrorContext location: got %+v, want %s:%d", ec, file, line)
+	}
+}
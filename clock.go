@@ -0,0 +1,33 @@
+package ewrap
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+//nolint:gochecknoglobals // package-wide clock override, swapped atomically
+var clockOverride atomic.Pointer[func() time.Time]
+
+// SetClock replaces the clock used to timestamp new errors, their
+// ErrorContext, and serialized output that has no creation time to fall back
+// on. It exists for reproducible output, chiefly golden tests that freeze
+// time. Passing nil restores time.Now. Errors created earlier keep their
+// timestamps.
+func SetClock(now func() time.Time) {
+	if now == nil {
+		clockOverride.Store(nil)
+
+		return
+	}
+
+	clockOverride.Store(&now)
+}
+
+// currentTime reads the clock set with SetClock, falling back to time.Now.
+func currentTime() time.Time {
+	if now := clockOverride.Load(); now != nil {
+		return (*now)()
+	}
+
+	return time.Now()
+}
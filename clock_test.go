@@ -0,0 +1,43 @@
+package ewrap
+
+import (
+	"testing"
+	"time"
+)
+
+//nolint:paralleltest // mutates the package-level clock
+func TestSetClock(t *testing.T) {
+	t.Cleanup(func() { SetClock(nil) })
+
+	frozen := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	SetClock(func() time.Time { return frozen })
+
+	err := New(msgTest, WithContext(t.Context(), ErrorTypeInternal, SeverityError))
+	want := frozen.Format(time.RFC3339)
+
+	for range defaultMaxAttempts {
+		if got := err.ToErrorOutput().Timestamp; got != want {
+			t.Fatalf("Timestamp: got %q, want %q", got, want)
+		}
+	}
+
+	if got := Wrap(err, msgWrapped).ToErrorOutput().Timestamp; got != want {
+		t.Errorf("wrapped Timestamp: got %q, want %q", got, want)
+	}
+
+	if got := err.GetErrorContext().Timestamp; !got.Equal(frozen) {
+		t.Errorf("ErrorContext.Timestamp: got %v, want %v", got, frozen)
+	}
+
+	// Serializing an error with no creation time also reads the clock.
+	if got := (&Error{msg: msgTest}).ToErrorOutput().Timestamp; got != want {
+		t.Errorf("fallback Timestamp: got %q, want %q", got, want)
+	}
+
+	SetClock(nil)
+
+	if New(msgTest).ToErrorOutput().Timestamp == want {
+		t.Error("expected SetClock(nil) to restore time.Now")
+	}
+}
@@ -0,0 +1,99 @@
+package ewrap
+
+import "errors"
+
+// Code is a machine-readable error code. Declare codes as typed constants
+// so a typo fails to compile instead of silently never matching:
+//
+//	const CodeNotFound ewrap.Code = "NOT_FOUND"
+type Code string
+
+// String returns the code as a plain string.
+func (c Code) String() string {
+	return string(c)
+}
+
+// WithCode tags the error with code. Wrap inherits it. An empty code is
+// ignored and reported to the error's observer if it implements
+// WarningObserver; for that, WithObserver must precede WithCode in the
+// option list.
+func WithCode(code Code) Option {
+	return func(err *Error) {
+		if code == "" {
+			if w, ok := err.observer.(WarningObserver); ok {
+				w.RecordWarning("ewrap: empty code passed to WithCode for " + err.msg)
+			}
+
+			return
+		}
+
+		err.code = code
+	}
+}
+
+// Code returns the first code found walking the chain from this error
+// inward, or "" if none is set.
+func (e *Error) Code() Code {
+	return codeOf(e)
+}
+
+// codeOf returns the first code set on an *Error in err's chain, or "".
+func codeOf(err error) Code {
+	for cur := err; cur != nil; cur = errors.Unwrap(cur) {
+		if layer, ok := cur.(*Error); ok && layer.code != "" {
+			return layer.code
+		}
+	}
+
+	return ""
+}
+
+// HasCode reports whether any error in err's chain carries code. Like
+// errors.Is, it matches a code set on an inner error from any wrapper,
+// including standard fmt.Errorf("%w") wrappers. An empty code never matches.
+func HasCode(err error, code Code) bool {
+	if code == "" {
+		return false
+	}
+
+	for cur := err; cur != nil; cur = errors.Unwrap(cur) {
+		if layer, ok := cur.(*Error); ok && layer.code == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Is lets errors.Is match by code: a target *Error that carries a code and
+// nothing else (no message, cause, context, metadata, tags or HTTP status)
+// matches any error in the chain with the same code, whatever the instance:
+//
+//	var ErrNotFound = ewrap.New("", ewrap.WithCode(CodeNotFound))
+//
+//	errors.Is(ewrap.Wrap(err, "loading user"), ErrNotFound)
+//
+// Any other target falls back to errors.Is's identity comparison, so
+// sentinels with a message keep matching only themselves.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok || t == nil || !t.isCodeOnly() {
+		return false
+	}
+
+	return e.code == t.code
+}
+
+// isCodeOnly reports whether e carries a code and no other field that would
+// tell two errors apart, which makes it a code-matching target for Is.
+func (e *Error) isCodeOnly() bool {
+	if e.code == "" || e.msg != "" || e.cause != nil || e.errorContext != nil ||
+		e.httpStatus != 0 || len(e.tags) > 0 {
+		return false
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return len(e.metadata) == 0
+}
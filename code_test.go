@@ -0,0 +1,125 @@
+package ewrap
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+const (
+	codeNotFound Code = "NOT_FOUND"
+	codeConflict Code = "CONFLICT"
+)
+
+// warningObserver implements WarningObserver for tests.
+type warningObserver struct {
+	recordingObserver
+
+	warnings []string
+}
+
+func (w *warningObserver) RecordWarning(message string) {
+	w.warnings = append(w.warnings, message)
+}
+
+func TestCodePropagation(t *testing.T) {
+	t.Parallel()
+
+	inner := New(msgOriginal, WithCode(codeNotFound))
+	outer := Wrap(fmt.Errorf("std layer: %w", Wrap(inner, msgFirst)), msgSecond)
+
+	if got := outer.Code(); got != codeNotFound {
+		t.Errorf("Code(): got %q, want %q", got, codeNotFound)
+	}
+
+	if got := Wrap(inner, msgWrapped, WithCode(codeConflict)).Code(); got != codeConflict {
+		t.Errorf("an explicit code on the wrapper should win, got %q", got)
+	}
+
+	if got := New(msgPlain).Code(); got != "" {
+		t.Errorf("expected no code, got %q", got)
+	}
+
+	if codeNotFound.String() != "NOT_FOUND" {
+		t.Errorf("String(): got %q", codeNotFound.String())
+	}
+}
+
+func TestHasCode(t *testing.T) {
+	t.Parallel()
+
+	inner := New(msgOriginal, WithCode(codeNotFound))
+	wrapped := fmt.Errorf("std layer: %w", Wrap(inner, msgWrapped, WithCode(codeConflict)))
+
+	for _, code := range []Code{codeNotFound, codeConflict} {
+		if !HasCode(wrapped, code) {
+			t.Errorf("expected %q to be found in the chain", code)
+		}
+	}
+
+	if HasCode(wrapped, "GONE") || HasCode(wrapped, "") || HasCode(nil, codeNotFound) {
+		t.Error("unexpected match")
+	}
+}
+
+func TestIsMatchesByCode(t *testing.T) {
+	t.Parallel()
+
+	notFound := New("", WithCode(codeNotFound))
+	conflict := New("", WithCode(codeConflict))
+
+	inner := New(msgOriginal, WithCode(codeNotFound))
+	wrapped := fmt.Errorf("std layer: %w", Wrap(inner, msgWrapped))
+
+	if !errors.Is(wrapped, notFound) {
+		t.Error("expected a code-only target to match by code through the chain")
+	}
+
+	if errors.Is(wrapped, conflict) {
+		t.Error("a different code must not match")
+	}
+
+	if errors.Is(New(msgOriginal), notFound) {
+		t.Error("an error without a code must not match")
+	}
+
+	if errors.Is(wrapped, New("", WithCode(codeNotFound), WithTags("billing"))) {
+		t.Error("a tagged target must not match by code")
+	}
+
+	// A target with a message is a sentinel, matched by identity only.
+	sentinel := New(msgTestError, WithCode(codeNotFound))
+	if errors.Is(inner, sentinel) {
+		t.Error("a sentinel with a message must not match by code")
+	}
+
+	if !errors.Is(Wrap(sentinel, msgWrapped), sentinel) {
+		t.Error("expected the sentinel to match itself through a wrapper")
+	}
+
+	if !errors.Is(Wrap(errSentinel, msgWrapped, WithCode(codeNotFound)), errSentinel) {
+		t.Error("expected plain sentinel matching to keep working")
+	}
+
+	if errors.Is(Wrap(errSentinel, msgWrapped), errOtherSentinel) {
+		t.Error("distinct sentinels with the same text must not match")
+	}
+}
+
+func TestWithCodeEmptyWarns(t *testing.T) {
+	t.Parallel()
+
+	obs := &warningObserver{}
+	err := New(msgTest, WithObserver(obs), WithCode(""))
+
+	if err.code != "" {
+		t.Errorf("an empty code must not be set, got %q", err.code)
+	}
+
+	if len(obs.warnings) != 1 {
+		t.Errorf("expected one warning, got %v", obs.warnings)
+	}
+
+	// An observer without RecordWarning is left alone.
+	_ = New(msgTest, WithObserver(&recordingObserver{}), WithCode(""))
+}
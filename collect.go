@@ -0,0 +1,52 @@
+package ewrap
+
+import "context"
+
+// Collect runs fns concurrently and gathers every non-nil result into an
+// ErrorGroup. Unlike errgroup it doesn't stop at the first failure; it stops
+// early only when ctx is done. Functions that haven't started by then are
+// skipped, and Collect returns at once with the context error recorded in
+// the group, without waiting for the ones still running. Their results are
+// discarded. The group is empty when every function succeeds.
+func Collect(ctx context.Context, fns ...func() error) *ErrorGroup {
+	return CollectLimit(ctx, 0, fns...)
+}
+
+// CollectLimit is Collect with at most limit functions running at a time.
+// A limit of 0 or less runs them all at once.
+func CollectLimit(ctx context.Context, limit int, fns ...func() error) *ErrorGroup {
+	group := NewErrorGroup()
+
+	if limit <= 0 || limit > len(fns) {
+		limit = len(fns)
+	}
+
+	// Buffered so that functions finishing after an early return never
+	// block on the send.
+	results := make(chan error, len(fns))
+
+	next, running := 0, 0
+	for next < len(fns) || running > 0 {
+		for running < limit && next < len(fns) && ctx.Err() == nil {
+			go func(fn func() error) {
+				results <- fn()
+			}(fns[next])
+
+			next++
+			running++
+		}
+
+		select {
+		case err := <-results:
+			running--
+
+			group.Add(err)
+		case <-ctx.Done():
+			group.Add(Wrapf(ctx.Err(), "collect stopped with %d of %d functions unfinished", len(fns)-next+running, len(fns)))
+
+			return group
+		}
+	}
+
+	return group
+}
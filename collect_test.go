@@ -0,0 +1,122 @@
+package ewrap
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCollectAllSuccess(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	fns := make([]func() error, concurrencyLimit)
+	for i := range fns {
+		fns[i] = func() error {
+			calls.Add(1)
+
+			return nil
+		}
+	}
+
+	group := Collect(context.Background(), fns...)
+
+	if group.HasErrors() {
+		t.Errorf("expected no errors, got %v", group.Errors())
+	}
+
+	if got := calls.Load(); got != concurrencyLimit {
+		t.Errorf("calls: got %d, want %d", got, concurrencyLimit)
+	}
+}
+
+func TestCollectSomeFailures(t *testing.T) {
+	t.Parallel()
+
+	group := Collect(context.Background(),
+		func() error { return errFirst },
+		func() error { return nil },
+		func() error { return errSecond },
+	)
+
+	errs := group.Errors()
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %d: %v", len(errs), errs)
+	}
+
+	if !errors.Is(group, errFirst) || !errors.Is(group, errSecond) {
+		t.Errorf("expected both failures in the group, got %v", errs)
+	}
+}
+
+func TestCollectContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	var started atomic.Int32
+
+	block := func() error {
+		started.Add(1)
+		<-release
+
+		return errOther
+	}
+
+	group := CollectLimit(ctx, 1, block, block, block)
+
+	if !errors.Is(group, context.DeadlineExceeded) {
+		t.Fatalf("expected the context error in the group, got %v", group.Errors())
+	}
+
+	if errors.Is(group, errOther) {
+		t.Error("results of unfinished functions must be discarded")
+	}
+
+	if got := started.Load(); got != 1 {
+		t.Errorf("expected only the first function to start, got %d", got)
+	}
+}
+
+func TestCollectLimit(t *testing.T) {
+	t.Parallel()
+
+	const limit = 2
+
+	var running, peak atomic.Int32
+
+	fn := func() error {
+		n := running.Add(1)
+		for {
+			old := peak.Load()
+			if n <= old || peak.CompareAndSwap(old, n) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+
+		return nil
+	}
+
+	fns := make([]func() error, limit*5)
+	for i := range fns {
+		fns[i] = fn
+	}
+
+	if group := CollectLimit(context.Background(), limit, fns...); group.HasErrors() {
+		t.Fatalf("expected no errors, got %v", group.Errors())
+	}
+
+	if got := peak.Load(); got > limit {
+		t.Errorf("peak concurrency: got %d, want at most %d", got, limit)
+	}
+}
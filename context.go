@@ -2,13 +2,20 @@ package ewrap
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"runtime"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 const (
+	// errorContextRuntimeCallers skips runtime.Callers and callerLocation so
+	// the walk starts at callerLocation's caller.
 	errorContextRuntimeCallers = 2
 )
 
@@ -35,22 +42,31 @@ type ErrorContext struct {
 	// File and line where the error occurred.
 	File string
 	Line int
+	// Deadline of the context.Context the error was created with, if it had
+	// one.
+	Deadline time.Time
+	// TimedOut reports whether that context's deadline had already been
+	// exceeded when the error was created.
+	TimedOut bool
 	// Additional context-specific data.
 	Data map[string]any
 }
 
-// newErrorContext creates a new ErrorContext with basic information.
-func newErrorContext(ctx context.Context, errorType ErrorType, severity Severity) *ErrorContext {
-	_, file, line, _ := runtime.Caller(errorContextRuntimeCallers)
+// newErrorContext creates a new ErrorContext with basic information. File
+// and Line point at the first frame outside ewrap, advanced by skip further
+// frames (see WithCallerSkip).
+func newErrorContext(ctx context.Context, errorType ErrorType, severity Severity, skip int) *ErrorContext {
+	file, line := callerLocation(skip)
 
 	errorCtx := &ErrorContext{
-		Timestamp:   time.Now(),
+		Timestamp:   currentTime(),
 		Type:        errorType,
 		Severity:    severity,
 		File:        file,
 		Line:        line,
 		Data:        make(map[string]any),
 		Environment: getEnvironment(),
+		Version:     currentVersion(),
 	}
 
 	if ctx != nil {
@@ -70,6 +86,12 @@ func newErrorContext(ctx context.Context, errorType ErrorType, severity Severity
 		if component, ok := ctx.Value("component").(string); ok {
 			errorCtx.Component = component
 		}
+
+		if deadline, ok := ctx.Deadline(); ok {
+			errorCtx.Deadline = deadline
+		}
+
+		errorCtx.TimedOut = errors.Is(ctx.Err(), context.DeadlineExceeded)
 	}
 
 	return errorCtx
@@ -93,8 +115,9 @@ func (ec *ErrorContext) String() string {
 // WithContext adds context information to the error.
 func WithContext(ctx context.Context, errorType ErrorType, severity Severity) Option {
 	return func(err *Error) {
-		errorCtx := newErrorContext(ctx, errorType, severity)
+		errorCtx := newErrorContext(ctx, errorType, severity, err.callerSkip)
 		err.errorContext = errorCtx
+		err.ownsContext = true
 
 		if err.logger != nil {
 			err.logger.Debug(
@@ -108,6 +131,148 @@ func WithContext(ctx context.Context, errorType ErrorType, severity Severity) Op
 	}
 }
 
+// WithExistingContext attaches ctx verbatim, without capturing caller or
+// context.Context information. Use it for a fully-populated ErrorContext
+// built elsewhere, such as one decoded from another service. It replaces any
+// context set by an earlier WithContext, and vice versa: the last one wins.
+//
+// The pointer is stored as-is, so ctx should not be mutated afterwards.
+// WithType and WithSeverity modify a copy and leave it untouched.
+func WithExistingContext(ctx *ErrorContext) Option {
+	return func(err *Error) {
+		err.errorContext = ctx
+		err.ownsContext = false
+	}
+}
+
+// callerLocation returns the file and line of the first call frame outside
+// ewrap, after skipping skip further frames. It returns zero values when the
+// stack is too shallow.
+func callerLocation(skip int) (string, int) {
+	pcs := make([]uintptr, defaultStackDepth)
+	n := runtime.Callers(errorContextRuntimeCallers, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	for {
+		frame, more := frames.Next()
+		if !isInternalFrame(frame) {
+			if skip <= 0 {
+				return frame.File, frame.Line
+			}
+
+			skip--
+		}
+
+		if !more {
+			return "", 0
+		}
+	}
+}
+
+// WithType sets the error's type without capturing caller or
+// context.Context information. It creates a minimal ErrorContext when none is
+// attached (severity defaults to SeverityError) and otherwise updates a copy
+// of the existing one, so it composes with WithSeverity and with a preceding
+// WithContext. WithContext replaces the whole context, so apply it first.
+func WithType(errorType ErrorType) Option {
+	return func(err *Error) {
+		err.ownErrorContext().Type = errorType
+	}
+}
+
+// WithSeverity sets the error's severity without capturing caller or
+// context.Context information. See WithType for how it composes with other
+// options.
+func WithSeverity(severity Severity) Option {
+	return func(err *Error) {
+		err.ownErrorContext().Severity = severity
+	}
+}
+
+// WithEscalateSeverity raises the error's severity to at least severity
+// and never lowers it. Pass it to Wrap when the wrapping operation treats a
+// minor cause as serious: the wrapper, and its serialized output, report the
+// higher level while the cause keeps its own. It composes with other options
+// like WithSeverity.
+func WithEscalateSeverity(severity Severity) Option {
+	return func(err *Error) {
+		ctx := err.ownErrorContext()
+		ctx.Severity = max(ctx.Severity, severity)
+	}
+}
+
+// WithOperation sets the operation recorded in the error's ErrorContext,
+// merging into an existing context as WithType does.
+func WithOperation(operation string) Option {
+	return func(err *Error) {
+		err.ownErrorContext().Operation = operation
+	}
+}
+
+// WithComponent sets the component recorded in the error's ErrorContext,
+// merging into an existing context as WithType does.
+func WithComponent(component string) Option {
+	return func(err *Error) {
+		err.ownErrorContext().Component = component
+	}
+}
+
+// WithUser sets the user recorded in the error's ErrorContext, merging into
+// an existing context as WithType does.
+func WithUser(user string) Option {
+	return func(err *Error) {
+		err.ownErrorContext().User = user
+	}
+}
+
+// WithRequestID sets the request ID recorded in the error's ErrorContext,
+// merging into an existing context as WithType does.
+func WithRequestID(requestID string) Option {
+	return func(err *Error) {
+		err.ownErrorContext().RequestID = requestID
+	}
+}
+
+// WithContextData stores key/value in the Data map of the error's
+// ErrorContext, creating a minimal context when none is attached. Unlike
+// WithMetadata it belongs to the context, so it travels with WithType,
+// WithSeverity and friends and is serialized under the context's "data"
+// key. A context shared with the wrapped error is copied first, so the inner
+// error is never modified. Like WithContext it mutates the error without
+// synchronization, so finish building the error before sharing it.
+func (e *Error) WithContextData(key string, value any) *Error {
+	ctx := e.ownErrorContext()
+	if ctx.Data == nil {
+		ctx.Data = make(map[string]any)
+	}
+
+	ctx.Data[key] = value
+
+	return e
+}
+
+// ownErrorContext returns an ErrorContext the error may mutate freely. Wrap
+// shares the inner error's context pointer, so a context the error does not
+// own yet is cloned once, on the first modification; later calls reuse it.
+func (e *Error) ownErrorContext() *ErrorContext {
+	switch {
+	case e.errorContext == nil:
+		e.errorContext = &ErrorContext{
+			Timestamp: currentTime(),
+			Type:      ErrorTypeUnknown,
+			Severity:  SeverityError,
+		}
+	case !e.ownsContext:
+		clone := *e.errorContext
+		clone.Data = maps.Clone(e.errorContext.Data)
+		e.errorContext = &clone
+	}
+
+	e.ownsContext = true
+
+	return e.errorContext
+}
+
 // getEnvironment determines the current runtime environment.
 func getEnvironment() string {
 	if env := os.Getenv("APP_ENV"); env != "" {
@@ -116,3 +281,43 @@ func getEnvironment() string {
 
 	return "development"
 }
+
+//nolint:gochecknoglobals // package-wide version override, swapped atomically
+var versionOverride atomic.Pointer[string]
+
+// SetVersion sets the application version recorded in ErrorContext.Version,
+// for builds without module version information (such as `go build` from a
+// checkout, which reports "(devel)"). Passing "" restores the version read
+// from the binary's build info. Errors created earlier keep their version.
+func SetVersion(version string) {
+	if version == "" {
+		versionOverride.Store(nil)
+
+		return
+	}
+
+	versionOverride.Store(&version)
+}
+
+// buildVersion is the main module version from the binary's build info, or
+// "" when it is unavailable or "(devel)". It is read once.
+//
+//nolint:gochecknoglobals // computed once from immutable build info
+var buildVersion = sync.OnceValue(func() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.Main.Version == "(devel)" {
+		return ""
+	}
+
+	return info.Main.Version
+})
+
+// currentVersion returns the version set with SetVersion, falling back to
+// the build info.
+func currentVersion() string {
+	if version := versionOverride.Load(); version != nil {
+		return *version
+	}
+
+	return buildVersion()
+}
@@ -0,0 +1,400 @@
+package ewrap
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+func TestWithTypeAndSeverity(t *testing.T) {
+	t.Parallel()
+
+	err := New(msgTestError, WithType(ErrorTypeNotFound), WithSeverity(SeverityWarning))
+
+	jsonStr, jsonErr := err.ToJSON()
+	if jsonErr != nil {
+		t.Fatalf(unexpectedErrFn, jsonErr)
+	}
+
+	var output ErrorOutput
+
+	unmarshalErr := json.Unmarshal([]byte(jsonStr), &output)
+	if unmarshalErr != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", unmarshalErr)
+	}
+
+	if output.Type != typeNotFoundStr {
+		t.Errorf("Expected type %q, got %q", typeNotFoundStr, output.Type)
+	}
+
+	if output.Severity != severityWarningStr {
+		t.Errorf("Expected severity %q, got %q", severityWarningStr, output.Severity)
+	}
+
+	ctx := err.GetErrorContext()
+	if ctx.File != "" || ctx.Line != 0 {
+		t.Errorf("expected no caller info, got %s:%d", ctx.File, ctx.Line)
+	}
+}
+
+func TestWithEscalateSeverity(t *testing.T) {
+	t.Parallel()
+
+	cause := New(msgOriginal, WithType(ErrorTypeDatabase), WithSeverity(SeverityWarning))
+	escalated := Wrap(cause, msgWrapped, WithEscalateSeverity(SeverityCritical))
+
+	jsonStr, jsonErr := escalated.ToJSON()
+	if jsonErr != nil {
+		t.Fatalf(unexpectedErrFn, jsonErr)
+	}
+
+	var output ErrorOutput
+	if unmarshalErr := json.Unmarshal([]byte(jsonStr), &output); unmarshalErr != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", unmarshalErr)
+	}
+
+	if output.Severity != severityCriticalStr {
+		t.Errorf("expected escalated severity %q, got %q", severityCriticalStr, output.Severity)
+	}
+
+	if output.Type != typeDatabaseStr {
+		t.Errorf("escalation must keep the inherited type, got %q", output.Type)
+	}
+
+	if output.Cause == nil || output.Cause.Severity != severityWarningStr {
+		t.Error("escalation must not change the cause's severity")
+	}
+
+	lowered := Wrap(escalated, msgWrapped, WithEscalateSeverity(SeverityInfo))
+	if got := lowered.SeverityLevel(); got != SeverityCritical {
+		t.Errorf("escalation must never lower the severity, got %v", got)
+	}
+}
+
+func TestWithTypeDefaultsSeverity(t *testing.T) {
+	t.Parallel()
+
+	err := New(msgTestError, WithType(ErrorTypeValidation))
+
+	if got := err.SeverityLevel(); got != SeverityError {
+		t.Errorf("expected default severity %v, got %v", SeverityError, got)
+	}
+}
+
+func TestWithTypeComposesWithContext(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.WithValue(context.Background(), "request_id", "req-1") //nolint:revive,staticcheck // mirrors newErrorContext keys
+
+	err := New(
+		msgTestError,
+		WithContext(ctx, ErrorTypeDatabase, SeverityError),
+		WithSeverity(SeverityCritical),
+	)
+
+	ec := err.GetErrorContext()
+	if ec.Type != ErrorTypeDatabase || ec.Severity != SeverityCritical {
+		t.Errorf("expected database/critical, got %v/%v", ec.Type, ec.Severity)
+	}
+
+	if ec.RequestID != "req-1" {
+		t.Errorf("expected WithContext fields to survive, got request id %q", ec.RequestID)
+	}
+}
+
+func TestWithTypeDoesNotMutateWrappedContext(t *testing.T) {
+	t.Parallel()
+
+	inner := New(msgRoot, WithType(ErrorTypeDatabase))
+	outer := Wrap(inner, msgWrapped, WithType(ErrorTypeNetwork))
+
+	if !outer.IsType(ErrorTypeNetwork) {
+		t.Error("expected wrapper to carry its own type")
+	}
+
+	if !inner.IsType(ErrorTypeDatabase) {
+		t.Error("setting the wrapper's type must not change the inner error")
+	}
+}
+
+// newFromHelper mimics an application-level error factory that should be
+// invisible in traces.
+//
+//go:noinline
+func newFromHelper(msg string) *Error {
+	return New(msg,
+		WithCallerSkip(1),
+		WithContext(context.Background(), ErrorTypeInternal, SeverityError))
+}
+
+func TestWithCallerSkip(t *testing.T) {
+	t.Parallel()
+
+	err := newFromHelper(msgTestError)
+	_, file, line, _ := runtime.Caller(0)
+	line-- // the call to newFromHelper is on the previous line
+
+	ec := err.GetErrorContext()
+	if ec.File != file || ec.Line != line {
+		t.Errorf("ErrorContext location: got %s:%d, want %s:%d", ec.File, ec.Line, file, line)
+	}
+
+	want := fmt.Sprintf("%s:%d - ", file, line)
+	if first, _, _ := strings.Cut(err.Stack(), "\n"); !strings.HasPrefix(first, want) {
+		t.Errorf("expected stack to start at the real caller %q, got %q", want, first)
+	}
+
+	frames := err.GetStackFrames()
+	if len(frames) == 0 || frames[0].Line != line {
+		t.Errorf("expected first stack frame at line %d, got %+v", line, frames)
+	}
+}
+
+func TestWithContextRecordsCallerLocation(t *testing.T) {
+	t.Parallel()
+
+	err := New(msgTestError, WithContext(context.Background(), ErrorTypeInternal, SeverityError))
+	_, file, line, _ := runtime.Caller(0)
+
+	if ec := err.GetErrorContext(); ec.File != file || ec.Line != line-1 {
+		t.Errorf("ErrorContext location: got %s:%d, want %s:%d", ec.File, ec.Line, file, line-1)
+	}
+}
+
+func TestWithExistingContext(t *testing.T) {
+	t.Parallel()
+
+	existing := &ErrorContext{
+		Type:      ErrorTypeExternal,
+		Severity:  SeverityCritical,
+		RequestID: "req-42",
+		File:      "remote.go",
+		Line:      7,
+	}
+
+	err := New(msgTestError, WithExistingContext(existing))
+	if got := err.GetErrorContext(); got != existing {
+		t.Fatalf("GetErrorContext: got %p, want %p", got, existing)
+	}
+
+	if existing.File != "remote.go" || existing.Line != 7 {
+		t.Errorf("existing context was modified: %+v", existing)
+	}
+
+	// Last one wins in either order.
+	err = New(msgTestError, WithContext(context.Background(), ErrorTypeDatabase, SeverityError), WithExistingContext(existing))
+	if err.GetErrorContext() != existing {
+		t.Error("WithExistingContext after WithContext should win")
+	}
+
+	err = New(msgTestError, WithExistingContext(existing), WithContext(context.Background(), ErrorTypeDatabase, SeverityError))
+	if err.GetErrorContext() == existing || !err.IsType(ErrorTypeDatabase) {
+		t.Error("WithContext after WithExistingContext should win")
+	}
+
+	err = New(msgTestError, WithExistingContext(existing), WithType(ErrorTypeNetwork))
+	if existing.Type != ErrorTypeExternal || !err.IsType(ErrorTypeNetwork) {
+		t.Errorf("WithType must update a copy: existing=%v", existing.Type)
+	}
+}
+
+func TestWithContextRecordsDeadline(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	want, _ := ctx.Deadline()
+
+	ec := New(msgTestError, WithContext(ctx, ErrorTypeNetwork, SeverityError)).GetErrorContext()
+	if !ec.Deadline.Equal(want) {
+		t.Errorf("Deadline: got %v, want %v", ec.Deadline, want)
+	}
+
+	if ec.TimedOut {
+		t.Error("TimedOut must be false while the deadline is in the future")
+	}
+
+	output := New(msgTestError, WithContext(ctx, ErrorTypeNetwork, SeverityError)).toErrorOutput()
+	if output.Context["deadline"] != want.Format(time.RFC3339Nano) || output.Context["timed_out"] != false {
+		t.Errorf("serialized context: got %v", output.Context)
+	}
+}
+
+func TestWithContextRecordsTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	ec := New(msgTestError, WithContext(ctx, ErrorTypeNetwork, SeverityError)).GetErrorContext()
+	if !ec.TimedOut {
+		t.Error("TimedOut must be true for an expired context")
+	}
+
+	background := New(msgTestError, WithContext(context.Background(), ErrorTypeNetwork, SeverityError))
+
+	ec = background.GetErrorContext()
+	if !ec.Deadline.IsZero() || ec.TimedOut {
+		t.Errorf("a context without deadline: got Deadline=%v TimedOut=%v", ec.Deadline, ec.TimedOut)
+	}
+
+	if _, ok := background.toErrorOutput().Context["deadline"]; ok {
+		t.Error("deadline must be omitted when the context has none")
+	}
+}
+
+func TestGranularContextOptions(t *testing.T) {
+	t.Parallel()
+
+	err := New(msgTestError,
+		WithType(ErrorTypeDatabase),
+		WithOperation("load_user"),
+		WithComponent("users"),
+		WithUser("u-1"),
+		WithRequestID("req-1"),
+	)
+
+	ec := err.GetErrorContext()
+	if ec == nil {
+		t.Fatal("expected an ErrorContext")
+	}
+
+	if ec.Operation != "load_user" || ec.Component != "users" || ec.User != "u-1" || ec.RequestID != "req-1" {
+		t.Errorf("fields not set: %+v", ec)
+	}
+
+	if ec.Type != ErrorTypeDatabase || ec.Severity != SeverityError {
+		t.Errorf("type/severity: got %v/%v", ec.Type, ec.Severity)
+	}
+}
+
+func TestGranularContextOptionsMerge(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.WithValue(context.Background(), "request_id", "from-ctx") //nolint:revive,staticcheck // mirrors newErrorContext keys
+
+	inner := New(msgTestError, WithContext(ctx, ErrorTypeNetwork, SeverityCritical))
+	outer := Wrap(inner, msgWrapped, WithComponent("gateway"))
+
+	ec := outer.GetErrorContext()
+	if ec.RequestID != "from-ctx" || ec.Component != "gateway" {
+		t.Errorf("expected merge, got %+v", ec)
+	}
+
+	if ec.Type != ErrorTypeNetwork || ec.Severity != SeverityCritical {
+		t.Errorf("existing fields lost: %v/%v", ec.Type, ec.Severity)
+	}
+
+	if inner.GetErrorContext().Component != "" {
+		t.Error("the wrapped error's context must not be mutated")
+	}
+}
+
+func TestWithContextData(t *testing.T) {
+	t.Parallel()
+
+	inner := New(msgOriginal, WithType(ErrorTypeDatabase))
+	err := Wrap(inner, msgWrapped).
+		WithContextData("table", "users").
+		WithContextData("rows", 3)
+
+	jsonStr, jsonErr := err.ToJSON()
+	if jsonErr != nil {
+		t.Fatalf(unexpectedErrFn, jsonErr)
+	}
+
+	var output struct {
+		Context struct {
+			Data map[string]any `json:"data"`
+		} `json:"context"`
+	}
+
+	if decodeErr := json.Unmarshal([]byte(jsonStr), &output); decodeErr != nil {
+		t.Fatalf("failed to decode JSON: %v", decodeErr)
+	}
+
+	if output.Context.Data["table"] != "users" || output.Context.Data["rows"] != float64(3) {
+		t.Errorf("context data missing from JSON: %v", output.Context.Data)
+	}
+
+	if !err.IsType(ErrorTypeDatabase) {
+		t.Error("WithContextData must keep the inherited context fields")
+	}
+
+	if len(inner.GetErrorContext().Data) != 0 {
+		t.Errorf("the inner error's context was modified: %v", inner.GetErrorContext().Data)
+	}
+
+	if New(msgTest).WithContextData(msgKey, msgValue).GetErrorContext().Data[msgKey] != msgValue {
+		t.Error("expected a context to be created when none is attached")
+	}
+}
+
+func TestContextClonedOnlyOnce(t *testing.T) {
+	t.Parallel()
+
+	inner := New(msgOriginal, WithType(ErrorTypeDatabase))
+	err := Wrap(inner, msgWrapped, WithSeverity(SeverityCritical), WithOperation("query"))
+
+	owned := err.GetErrorContext()
+	if owned == inner.GetErrorContext() {
+		t.Fatal("the wrapper must copy the inherited context before modifying it")
+	}
+
+	err.WithContextData("table", "users").WithContextData("rows", 3)
+
+	if err.GetErrorContext() != owned {
+		t.Error("an owned context must be modified in place, not copied again")
+	}
+
+	if inner.SeverityLevel() != SeverityError || len(inner.GetErrorContext().Data) != 0 {
+		t.Error("the inner error's context was modified")
+	}
+
+	shared := &ErrorContext{Type: ErrorTypeNetwork}
+	external := New(msgTest, WithExistingContext(shared), WithOperation("dial"))
+
+	if shared.Operation != "" || external.GetErrorContext().Operation != "dial" {
+		t.Error("a caller-supplied context must be copied before it is modified")
+	}
+}
+
+//nolint:paralleltest // mutates the package-level version override
+func TestSetVersion(t *testing.T) {
+	const version = "v1.4.2"
+
+	SetVersion(version)
+	t.Cleanup(func() { SetVersion("") })
+
+	err := New(msgTestError, WithContext(context.Background(), ErrorTypeInternal, SeverityError))
+
+	if got := err.GetErrorContext().Version; got != version {
+		t.Errorf("expected version %q on the context, got %q", version, got)
+	}
+
+	jsonStr, jsonErr := err.ToJSON()
+	if jsonErr != nil {
+		t.Fatalf(unexpectedErrFn, jsonErr)
+	}
+
+	var output ErrorOutput
+	if unmarshalErr := json.Unmarshal([]byte(jsonStr), &output); unmarshalErr != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", unmarshalErr)
+	}
+
+	if got := output.Context["version"]; got != version {
+		t.Errorf("expected version %q in serialized context, got %v", version, got)
+	}
+
+	SetVersion("")
+
+	if got := currentVersion(); got != buildVersion() {
+		t.Errorf("SetVersion(\"\") should restore the build version, got %q", got)
+	}
+}
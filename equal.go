@@ -0,0 +1,104 @@
+package ewrap
+
+import (
+	"reflect"
+	"slices"
+)
+
+// equalConfig collects EqualOption settings.
+type equalConfig struct {
+	metadata bool
+}
+
+// EqualOption configures (*Error).Equal.
+type EqualOption func(*equalConfig)
+
+// CompareMetadata makes Equal also require identical user metadata on every
+// layer, compared with reflect.DeepEqual.
+func CompareMetadata() EqualOption {
+	return func(cfg *equalConfig) {
+		cfg.metadata = true
+	}
+}
+
+// Equal reports whether e and other are semantically the same error: equal
+// messages, HTTP status, code, tags, type and severity on every layer, and
+// equal cause chains. Stacks, timestamps and other capture details are
+// ignored. Causes that are not *Error are equal when their Error() text
+// matches. This is distinct from errors.Is, which tests chain membership.
+func (e *Error) Equal(other *Error, opts ...EqualOption) bool {
+	cfg := equalConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return e.equal(other, &cfg)
+}
+
+func (e *Error) equal(other *Error, cfg *equalConfig) bool {
+	if e == nil || other == nil {
+		return e == other
+	}
+
+	if e == other {
+		return true
+	}
+
+	if e.msg != other.msg || e.httpStatus != other.httpStatus || e.code != other.code {
+		return false
+	}
+
+	if !slices.Equal(e.tags, other.tags) {
+		return false
+	}
+
+	if layerType(e) != layerType(other) || layerSeverity(e) != layerSeverity(other) {
+		return false
+	}
+
+	if cfg.metadata && !reflect.DeepEqual(e.Metadata(), other.Metadata()) {
+		return false
+	}
+
+	return causesEqual(e.cause, other.cause, cfg)
+}
+
+// causesEqual compares two causes, recursing through *Error layers.
+func causesEqual(a, b error, cfg *equalConfig) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	ea, aIsEwrap := a.(*Error)
+	eb, bIsEwrap := b.(*Error)
+
+	if aIsEwrap != bIsEwrap {
+		return false
+	}
+
+	if aIsEwrap {
+		return ea.equal(eb, cfg)
+	}
+
+	return a.Error() == b.Error()
+}
+
+// layerType returns the type recorded on this layer alone, defaulting to
+// ErrorTypeUnknown.
+func layerType(e *Error) ErrorType {
+	if e.errorContext == nil {
+		return ErrorTypeUnknown
+	}
+
+	return e.errorContext.Type
+}
+
+// layerSeverity returns the severity recorded on this layer alone,
+// defaulting to SeverityError.
+func layerSeverity(e *Error) Severity {
+	if e.errorContext == nil {
+		return SeverityError
+	}
+
+	return e.errorContext.Severity
+}
@@ -0,0 +1,94 @@
+package ewrap
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestErrorEqual(t *testing.T) {
+	t.Parallel()
+
+	build := func(rootMsg, outerMsg string) *Error {
+		return Wrap(Wrap(errRoot, rootMsg, WithType(ErrorTypeDatabase)), outerMsg)
+	}
+
+	t.Run("equal errors", func(t *testing.T) {
+		t.Parallel()
+
+		a, b := build(msgFirst, msgWrapped), build(msgFirst, msgWrapped)
+		if !a.Equal(b) {
+			t.Error("expected independently built identical chains to be equal")
+		}
+
+		var nilErr *Error
+		if !nilErr.Equal(nil) || a.Equal(nil) {
+			t.Error("expected nil to equal only nil")
+		}
+	})
+
+	t.Run("differing messages", func(t *testing.T) {
+		t.Parallel()
+
+		if build(msgFirst, msgWrapped).Equal(build(msgFirst, "other")) {
+			t.Error("expected differing outer messages to be unequal")
+		}
+	})
+
+	t.Run("differing causes", func(t *testing.T) {
+		t.Parallel()
+
+		if build(msgFirst, msgWrapped).Equal(build(msgSecond, msgWrapped)) {
+			t.Error("expected differing inner messages to be unequal")
+		}
+
+		if Wrap(errFirst, msgWrapped).Equal(Wrap(errSecond, msgWrapped)) {
+			t.Error("expected differing standard causes to be unequal")
+		}
+
+		if Wrap(errFirst, msgWrapped).Equal(Wrap(New(msgFirst), msgWrapped)) {
+			t.Error("expected an ewrap cause to differ from a standard one")
+		}
+	})
+
+	t.Run("differing classification", func(t *testing.T) {
+		t.Parallel()
+
+		a := New(msgTest, WithType(ErrorTypeNetwork))
+		b := New(msgTest, WithType(ErrorTypeNetwork), WithSeverity(SeverityCritical))
+
+		if a.Equal(b) {
+			t.Error("expected differing severities to be unequal")
+		}
+
+		if New(msgTest, WithHTTPStatus(http.StatusNotFound)).Equal(New(msgTest)) {
+			t.Error("expected differing HTTP status to be unequal")
+		}
+
+		if New(msgTest, WithCode(codeNotFound)).Equal(New(msgTest, WithCode(codeConflict))) {
+			t.Error("expected differing codes to be unequal")
+		}
+
+		if New(msgTest, WithTags(msgFirst)).Equal(New(msgTest, WithTags(msgSecond))) {
+			t.Error("expected differing tags to be unequal")
+		}
+	})
+
+	t.Run("metadata only with option", func(t *testing.T) {
+		t.Parallel()
+
+		a := New(msgTest).WithMetadata(msgKey, msgValue)
+		b := New(msgTest).WithMetadata(msgKey, "different")
+
+		if !a.Equal(b) {
+			t.Error("expected metadata to be ignored by default")
+		}
+
+		if a.Equal(b, CompareMetadata()) {
+			t.Error("expected differing metadata to be unequal with CompareMetadata")
+		}
+
+		if !a.Equal(New(msgTest).WithMetadata(msgKey, msgValue), CompareMetadata()) {
+			t.Error("expected identical metadata to be equal with CompareMetadata")
+		}
+	})
+}
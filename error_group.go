@@ -1,16 +1,19 @@
 package ewrap
 
 import (
+	"cmp"
+	"encoding/csv"
 	"errors"
 	"fmt"
 	"log/slog"
-	"maps"
+	"reflect"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/goccy/go-json"
 	"gopkg.in/yaml.v3"
 )
 
@@ -24,6 +27,23 @@ const (
 // and configuration while maintaining encapsulation.
 type ErrorGroupPool struct {
 	pool sync.Pool
+
+	gets   atomic.Uint64
+	allocs atomic.Uint64
+	puts   atomic.Uint64
+}
+
+// PoolStats is a snapshot of ErrorGroupPool activity counters.
+type PoolStats struct {
+	// Gets is the number of Get calls.
+	Gets uint64
+	// Hits is the number of Get calls served by a recycled group.
+	Hits uint64
+	// Allocations is the number of groups newly allocated because the pool
+	// was empty.
+	Allocations uint64
+	// Puts is the number of groups returned to the pool via Release.
+	Puts uint64
 }
 
 // NewErrorGroupPool creates a new pool for error groups with the specified
@@ -33,20 +53,23 @@ func NewErrorGroupPool(initialCapacity int) *ErrorGroupPool {
 		initialCapacity = poolCapacity // sensible default if given invalid capacity
 	}
 
-	return &ErrorGroupPool{
-		pool: sync.Pool{
-			New: func() any {
-				return &ErrorGroup{
-					errors: make([]error, 0, initialCapacity),
-					pool:   nil, // Will be set when retrieved from pool
-				}
-			},
-		},
+	p := &ErrorGroupPool{}
+	p.pool.New = func() any {
+		p.allocs.Add(1)
+
+		return &ErrorGroup{
+			errors: make([]error, 0, initialCapacity),
+			pool:   nil, // Will be set when retrieved from pool
+		}
 	}
+
+	return p
 }
 
 // Get retrieves an ErrorGroup from the pool or creates a new one if the pool is empty.
 func (p *ErrorGroupPool) Get() *ErrorGroup {
+	p.gets.Add(1)
+
 	eg, ok := p.pool.Get().(*ErrorGroup)
 	if !ok {
 		// log and return a new ErrorGroup skipping the pool.
@@ -65,7 +88,30 @@ func (p *ErrorGroupPool) Get() *ErrorGroup {
 func (p *ErrorGroupPool) put(eg *ErrorGroup) {
 	eg.Clear()
 	eg.pool = nil // Clear pool reference to prevent memory leaks
+	eg.WithMaxErrorsInMessage(0)
 	p.pool.Put(eg)
+	p.puts.Add(1)
+}
+
+// Stats returns a snapshot of the pool's activity counters. A low hit ratio
+// (Hits/Gets) suggests groups are not being released, or that the garbage
+// collector is draining the pool between bursts. The counters are read
+// independently, so a snapshot taken under concurrent use is approximate.
+func (p *ErrorGroupPool) Stats() PoolStats {
+	gets := p.gets.Load()
+	allocs := p.allocs.Load()
+
+	var hits uint64
+	if gets > allocs {
+		hits = gets - allocs
+	}
+
+	return PoolStats{
+		Gets:        gets,
+		Hits:        hits,
+		Allocations: allocs,
+		Puts:        p.puts.Load(),
+	}
 }
 
 // ErrorGroup represents a collection of related errors.
@@ -75,7 +121,9 @@ func (p *ErrorGroupPool) put(eg *ErrorGroup) {
 type ErrorGroup struct {
 	errors []error
 	pool   *ErrorGroupPool // Reference to the pool this group came from
-	mu     sync.RWMutex
+	// maxInMessage caps how many errors Error() lists; 0 lists them all.
+	maxInMessage int
+	mu           sync.RWMutex
 }
 
 // NewErrorGroup creates a standalone ErrorGroup without pooling.
@@ -86,6 +134,16 @@ func NewErrorGroup() *ErrorGroup {
 	}
 }
 
+// ErrorGroupFromErrors creates a standalone ErrorGroup holding the non-nil
+// errors in errs, in order. It suits collecting the results of a fan-out
+// into a []error.
+func ErrorGroupFromErrors(errs ...error) *ErrorGroup {
+	eg := NewErrorGroup()
+	eg.AddAll(errs...)
+
+	return eg
+}
+
 // Release returns the ErrorGroup to its pool if it came from one.
 // If the ErrorGroup wasn't created from a pool, Release is a no-op.
 func (eg *ErrorGroup) Release() {
@@ -105,6 +163,97 @@ func (eg *ErrorGroup) Add(err error) {
 	eg.mu.Unlock()
 }
 
+// AddAll appends every non-nil error in errs to the group, in order, under a
+// single lock acquisition.
+func (eg *ErrorGroup) AddAll(errs ...error) {
+	eg.mu.Lock()
+
+	for _, err := range errs {
+		if err != nil {
+			eg.errors = append(eg.errors, err)
+		}
+	}
+
+	eg.mu.Unlock()
+}
+
+// WrapEach replaces every error in the group with Wrap(err, msg, opts...),
+// so a batch failure can prefix each member with the same context. Each
+// wrapper records the stack of the WrapEach call. Ewrap attributes such as
+// metadata are inherited as with Wrap.
+func (eg *ErrorGroup) WrapEach(msg string, opts ...Option) {
+	eg.mu.Lock()
+	defer eg.mu.Unlock()
+
+	for i, err := range eg.errors {
+		if err != nil {
+			eg.errors[i] = wrapAt(callerSkipNew, err, msg, opts...)
+		}
+	}
+}
+
+// Flatten replaces every member that is itself an *ErrorGroup with that
+// group's errors, recursively, so reporting sees a single flat list in the
+// original order. Nested groups are left untouched. A group that contains
+// itself, directly or through its members, contributes nothing the second
+// time it is reached.
+func (eg *ErrorGroup) Flatten() {
+	eg.mu.Lock()
+	defer eg.mu.Unlock()
+
+	if !slices.ContainsFunc(eg.errors, isErrorGroup) {
+		return
+	}
+
+	eg.errors = flattenGroups(eg.errors, map[*ErrorGroup]bool{eg: true})
+}
+
+// isErrorGroup reports whether err is an *ErrorGroup.
+func isErrorGroup(err error) bool {
+	_, ok := err.(*ErrorGroup)
+
+	return ok
+}
+
+// flattenGroups splices the members of nested groups into errs. ancestors
+// holds the groups being flattened further up, which are skipped to break
+// cycles.
+func flattenGroups(errs []error, ancestors map[*ErrorGroup]bool) []error {
+	out := make([]error, 0, len(errs))
+
+	for _, err := range errs {
+		child, ok := err.(*ErrorGroup)
+		if !ok {
+			out = append(out, err)
+
+			continue
+		}
+
+		if ancestors[child] {
+			continue
+		}
+
+		ancestors[child] = true
+		out = append(out, flattenGroups(child.Errors(), ancestors)...)
+		delete(ancestors, child)
+	}
+
+	return out
+}
+
+// WithMaxErrorsInMessage caps how many errors Error() lists. Beyond n, the
+// message ends with a "... and M more" line instead, which keeps a group of
+// thousands of errors from producing a multi-megabyte log line. Errors(),
+// the iterators and the serialization methods still return every error.
+// n <= 0 removes the cap. It returns the group for chaining.
+func (eg *ErrorGroup) WithMaxErrorsInMessage(n int) *ErrorGroup {
+	eg.mu.Lock()
+	eg.maxInMessage = max(n, 0)
+	eg.mu.Unlock()
+
+	return eg
+}
+
 // HasErrors returns true if the group contains any errors.
 func (eg *ErrorGroup) HasErrors() bool {
 	eg.mu.RLock()
@@ -130,10 +279,19 @@ func (eg *ErrorGroup) Error() string {
 
 		fmt.Fprintf(&builder, "%d errors occurred:\n", len(eg.errors))
 
-		for i, err := range eg.errors {
+		listed := eg.errors
+		if eg.maxInMessage > 0 && len(listed) > eg.maxInMessage {
+			listed = listed[:eg.maxInMessage]
+		}
+
+		for i, err := range listed {
 			fmt.Fprintf(&builder, "%d: %s\n", i+1, err.Error())
 		}
 
+		if hidden := len(eg.errors) - len(listed); hidden > 0 {
+			fmt.Fprintf(&builder, "... and %d more\n", hidden)
+		}
+
 		return builder.String()
 	}
 }
@@ -155,6 +313,29 @@ func (eg *ErrorGroup) Errors() []error {
 	return slices.Clone(eg.errors)
 }
 
+// Each calls fn for every error in the group, in insertion order, with its
+// index. Iteration stops as soon as fn returns false. Unlike Errors, Each
+// does not copy the slice: it runs under the group's read lock, so fn must
+// not call Add, Clear, or any other mutating method on the same group, or it
+// will deadlock.
+func (eg *ErrorGroup) Each(fn func(int, error) bool) {
+	eg.mu.RLock()
+	defer eg.mu.RUnlock()
+
+	for i, err := range eg.errors {
+		if !fn(i, err) {
+			return
+		}
+	}
+}
+
+// Unwrap returns a copy of the members, so errors.Is and errors.As search
+// every branch of the group, including nested groups and multi-cause
+// errors, the same way they do on Join's result.
+func (eg *ErrorGroup) Unwrap() []error {
+	return eg.Errors()
+}
+
 // Join aggregates all errors in the group using errors.Join.
 // It returns nil if the group is empty.
 func (eg *ErrorGroup) Join() error {
@@ -164,6 +345,155 @@ func (eg *ErrorGroup) Join() error {
 	return errors.Join(eg.errors...)
 }
 
+// JoinWith aggregates all errors in the group into a single error whose
+// message is the member messages joined by sep. When dedup is true, repeated
+// messages are emitted only once (first occurrence wins) so output stays
+// deterministic. Deduplication affects the message only: the returned error
+// still unwraps to every member, so errors.Is and errors.As see them all.
+// It returns nil if the group is empty.
+func (eg *ErrorGroup) JoinWith(sep string, dedup bool) error {
+	eg.mu.RLock()
+	defer eg.mu.RUnlock()
+
+	if len(eg.errors) == 0 {
+		return nil
+	}
+
+	return &joinedError{
+		errs:  slices.Clone(eg.errors),
+		sep:   sep,
+		dedup: dedup,
+	}
+}
+
+// FilterBySeverity returns a new, unpooled ErrorGroup holding only the errors
+// whose severity is at least minSeverity. Errors without an ErrorContext,
+// including standard library errors, are treated as SeverityError.
+func (eg *ErrorGroup) FilterBySeverity(minSeverity Severity) *ErrorGroup {
+	eg.mu.RLock()
+	defer eg.mu.RUnlock()
+
+	filtered := NewErrorGroup()
+
+	for _, err := range eg.errors {
+		if severityOf(err) >= minSeverity {
+			filtered.errors = append(filtered.errors, err)
+		}
+	}
+
+	return filtered
+}
+
+// HighestSeverity returns the most severe level found in the group, using
+// the same classification as FilterBySeverity. An empty group reports
+// SeverityInfo.
+func (eg *ErrorGroup) HighestSeverity() Severity {
+	eg.mu.RLock()
+	defer eg.mu.RUnlock()
+
+	highest := SeverityInfo
+
+	for _, err := range eg.errors {
+		highest = max(highest, severityOf(err))
+	}
+
+	return highest
+}
+
+// Any reports whether pred holds for at least one error in the group,
+// stopping at the first match. It runs under the read lock, so pred must not
+// modify the group.
+func (eg *ErrorGroup) Any(pred func(error) bool) bool {
+	eg.mu.RLock()
+	defer eg.mu.RUnlock()
+
+	return slices.ContainsFunc(eg.errors, pred)
+}
+
+// All reports whether pred holds for every error in the group, stopping at
+// the first miss. An empty group reports true. It runs under the read lock,
+// so pred must not modify the group.
+func (eg *ErrorGroup) All(pred func(error) bool) bool {
+	eg.mu.RLock()
+	defer eg.mu.RUnlock()
+
+	for _, err := range eg.errors {
+		if !pred(err) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SortBySeverity reorders the group from most to least severe, using the
+// same classification as FilterBySeverity. The sort is stable: errors of
+// equal severity keep their insertion order.
+func (eg *ErrorGroup) SortBySeverity() {
+	eg.mu.Lock()
+	defer eg.mu.Unlock()
+
+	slices.SortStableFunc(eg.errors, func(a, b error) int {
+		return cmp.Compare(severityOf(b), severityOf(a))
+	})
+}
+
+// SortByType reorders the group by ErrorType, in the order the types are
+// declared, so errors of the same type sit together. Errors without an
+// ErrorContext count as ErrorTypeUnknown. The sort is stable.
+func (eg *ErrorGroup) SortByType() {
+	eg.mu.Lock()
+	defer eg.mu.Unlock()
+
+	slices.SortStableFunc(eg.errors, func(a, b error) int {
+		return cmp.Compare(typeOf(a), typeOf(b))
+	})
+}
+
+// Remove deletes the first member that is target itself or, failing that,
+// the first whose Error() text equals target's, and reports whether one was
+// removed. Use it to drop an error once it has been handled.
+func (eg *ErrorGroup) Remove(target error) bool {
+	if target == nil {
+		return false
+	}
+
+	eg.mu.Lock()
+	defer eg.mu.Unlock()
+
+	// Comparing interfaces panics when both hold the same uncomparable type.
+	i := -1
+	if reflect.TypeOf(target).Comparable() {
+		i = slices.IndexFunc(eg.errors, func(err error) bool { return err == target })
+	}
+
+	if i < 0 {
+		msg := target.Error()
+		i = slices.IndexFunc(eg.errors, func(err error) bool { return err.Error() == msg })
+	}
+
+	if i < 0 {
+		return false
+	}
+
+	eg.errors = slices.Delete(eg.errors, i, i+1)
+
+	return true
+}
+
+// RemoveFunc deletes every member for which pred returns true and reports
+// how many were removed. pred runs under the group's write lock, so it must
+// not call back into the same group.
+func (eg *ErrorGroup) RemoveFunc(pred func(error) bool) int {
+	eg.mu.Lock()
+	defer eg.mu.Unlock()
+
+	before := len(eg.errors)
+	eg.errors = slices.DeleteFunc(eg.errors, pred)
+
+	return before - len(eg.errors)
+}
+
 // Clear removes all errors from the group while preserving capacity.
 func (eg *ErrorGroup) Clear() {
 	eg.mu.Lock()
@@ -171,13 +501,105 @@ func (eg *ErrorGroup) Clear() {
 	eg.mu.Unlock()
 }
 
+// joinedError is the multi-cause error returned by JoinWith.
+//
+//nolint:errname
+type joinedError struct {
+	errs  []error
+	sep   string
+	dedup bool
+}
+
+// Error joins member messages with the configured separator.
+func (j *joinedError) Error() string {
+	var (
+		builder strings.Builder
+		seen    map[string]struct{}
+	)
+
+	if j.dedup {
+		seen = make(map[string]struct{}, len(j.errs))
+	}
+
+	for _, err := range j.errs {
+		msg := err.Error()
+
+		if seen != nil {
+			if _, dup := seen[msg]; dup {
+				continue
+			}
+
+			seen[msg] = struct{}{}
+		}
+
+		if builder.Len() > 0 {
+			builder.WriteString(j.sep)
+		}
+
+		builder.WriteString(msg)
+	}
+
+	return builder.String()
+}
+
+// Unwrap exposes every member so errors.Is and errors.As walk them all.
+func (j *joinedError) Unwrap() []error {
+	return j.errs
+}
+
 // SerializableError represents an error in a serializable format.
+// OmittedFrames counts the stack frames dropped by WithMaxStackFrames.
 type SerializableError struct {
-	Message    string             `json:"message"               yaml:"message"`
-	Type       string             `json:"type"                  yaml:"type"`
-	StackTrace []StackFrame       `json:"stack_trace,omitempty" yaml:"stack_trace,omitempty"`
-	Metadata   map[string]any     `json:"metadata,omitempty"    yaml:"metadata,omitempty"`
-	Cause      *SerializableError `json:"cause,omitempty"       yaml:"cause,omitempty"`
+	Message       string             `json:"message"                  yaml:"message"`
+	Type          string             `json:"type"                     yaml:"type"`
+	StackTrace    []StackFrame       `json:"stack_trace,omitempty"    yaml:"stack_trace,omitempty"`
+	OmittedFrames int                `json:"omitted_frames,omitempty" yaml:"omitted_frames,omitempty"`
+	Metadata      map[string]any     `json:"metadata,omitempty"       yaml:"metadata,omitempty"`
+	Cause         *SerializableError `json:"cause,omitempty"          yaml:"cause,omitempty"`
+
+	// compactStack renders StackTrace as one string per frame in YAML. Set
+	// via WithCompactStack.
+	compactStack bool
+}
+
+// compactSerializableError is the YAML shape of a SerializableError under
+// WithCompactStack: identical except for the one-line frames.
+type compactSerializableError struct {
+	Message       string             `yaml:"message"`
+	Type          string             `yaml:"type"`
+	StackTrace    []string           `yaml:"stack_trace,omitempty"`
+	OmittedFrames int                `yaml:"omitted_frames,omitempty"`
+	Metadata      map[string]any     `yaml:"metadata,omitempty"`
+	Cause         *SerializableError `yaml:"cause,omitempty"`
+}
+
+// MarshalYAML implements yaml.Marshaler. Frames are mappings of their
+// fields unless WithCompactStack was set, in which case each is a single
+// "file:line function" string.
+func (se SerializableError) MarshalYAML() (any, error) {
+	// plain drops this method so the default encoding applies.
+	type plain SerializableError
+
+	if !se.compactStack {
+		return plain(se), nil
+	}
+
+	var frames []string
+	if len(se.StackTrace) > 0 {
+		frames = make([]string, len(se.StackTrace))
+		for i, frame := range se.StackTrace {
+			frames[i] = fmt.Sprintf("%s:%d %s", frame.File, frame.Line, frame.Function)
+		}
+	}
+
+	return compactSerializableError{
+		Message:       se.Message,
+		Type:          se.Type,
+		StackTrace:    frames,
+		OmittedFrames: se.OmittedFrames,
+		Metadata:      se.Metadata,
+		Cause:         se.Cause,
+	}, nil
 }
 
 // ErrorGroupSerialization represents the serializable format of an ErrorGroup.
@@ -187,35 +609,82 @@ type ErrorGroupSerialization struct {
 	Errors     []SerializableError `json:"errors"      yaml:"errors"`
 }
 
+// groupFormat is the subset of FormatOption effects that applies to group
+// serialization.
+type groupFormat struct {
+	includeStack     bool
+	maxStackFrames   int
+	minStackSeverity Severity
+	compactStack     bool
+	timestamp        string
+}
+
+// resolveGroupFormat applies opts to a probe ErrorOutput and reads back the
+// effects, so groups honor the same FormatOption values as single errors.
+func resolveGroupFormat(opts []FormatOption) groupFormat {
+	now := currentTime()
+	// The probe is critical so WithStackTraceFrom records its threshold
+	// without blanking the stack; members are checked one by one.
+	probe := &ErrorOutput{
+		Stack:     "probe",
+		stack:     "probe",
+		Timestamp: now.Format(time.RFC3339),
+		timestamp: now,
+		severity:  SeverityCritical,
+	}
+
+	for _, opt := range withDefaultFormatOptions(opts) {
+		opt(probe)
+	}
+
+	return groupFormat{
+		includeStack:     probe.Stack != "",
+		maxStackFrames:   probe.maxStackFrames,
+		minStackSeverity: probe.minStackSeverity,
+		compactStack:     probe.compactStack,
+		timestamp:        probe.Timestamp,
+	}
+}
+
 // toSerializableError converts an error to a SerializableError. The cause
 // chain is preserved for both *Error and standard wrapped errors via
 // errors.Unwrap so transport consumers do not lose context at boundaries.
-func toSerializableError(err error) SerializableError {
+// Stack traces are omitted throughout the chain when format.includeStack is
+// false, on errors below format.minStackSeverity, and capped at
+// format.maxStackFrames frames when that is positive.
+func toSerializableError(err error, format groupFormat) SerializableError {
 	if err == nil {
 		return SerializableError{}
 	}
 
 	serErr := SerializableError{
-		Message: err.Error(),
-		Type:    "standard",
+		Message:      err.Error(),
+		Type:         "standard",
+		compactStack: format.compactStack,
 	}
 
-	customErr := &Error{}
-	if errors.As(err, &customErr) {
+	if customErr, ok := err.(*Error); ok {
 		serErr.Type = "ewrap"
-		serErr.StackTrace = customErr.GetStackFrames()
+
+		if format.includeStack && severityOf(customErr) >= format.minStackSeverity {
+			serErr.StackTrace = customErr.GetStackFrames()
+
+			if limit := format.maxStackFrames; limit > 0 && len(serErr.StackTrace) > limit {
+				serErr.OmittedFrames = len(serErr.StackTrace) - limit
+				serErr.StackTrace = serErr.StackTrace[:limit]
+			}
+		}
 
 		customErr.mu.RLock()
 
 		if len(customErr.metadata) > 0 {
-			serErr.Metadata = make(map[string]any, len(customErr.metadata))
-			maps.Copy(serErr.Metadata, customErr.metadata)
+			serErr.Metadata = serializedMetadata(customErr.metadata)
 		}
 
 		customErr.mu.RUnlock()
 
 		if customErr.cause != nil {
-			cause := toSerializableError(customErr.cause)
+			cause := toSerializableError(customErr.cause, format)
 			serErr.Cause = &cause
 		}
 
@@ -224,36 +693,44 @@ func toSerializableError(err error) SerializableError {
 
 	cause := errors.Unwrap(err)
 	if cause != nil {
-		c := toSerializableError(cause)
+		c := toSerializableError(cause, format)
 		serErr.Cause = &c
 	}
 
 	return serErr
 }
 
-// ToSerialization converts the ErrorGroup to a serializable format.
-func (eg *ErrorGroup) ToSerialization() ErrorGroupSerialization {
+// ToSerialization converts the ErrorGroup to a serializable format. Format
+// options apply as for ToJSON, which lets encoders outside this package
+// produce the same shape.
+func (eg *ErrorGroup) ToSerialization(opts ...FormatOption) ErrorGroupSerialization {
+	return eg.toSerialization(resolveGroupFormat(opts))
+}
+
+func (eg *ErrorGroup) toSerialization(format groupFormat) ErrorGroupSerialization {
 	eg.mu.RLock()
 	defer eg.mu.RUnlock()
 
 	serializable := ErrorGroupSerialization{
 		ErrorCount: len(eg.errors),
-		Timestamp:  time.Now().Format(time.RFC3339),
+		Timestamp:  format.timestamp,
 		Errors:     make([]SerializableError, len(eg.errors)),
 	}
 
 	for i, err := range eg.errors {
-		serializable.Errors[i] = toSerializableError(err)
+		serializable.Errors[i] = toSerializableError(err, format)
 	}
 
 	return serializable
 }
 
-// ToJSON converts the ErrorGroup to JSON format.
-func (eg *ErrorGroup) ToJSON() (string, error) {
-	serializable := eg.ToSerialization()
+// ToJSON converts the ErrorGroup to JSON format. Format options apply as
+// for (*Error).ToJSON: WithStackTrace(false) omits every member's stack
+// trace and WithTimestampFormat formats the group timestamp.
+func (eg *ErrorGroup) ToJSON(opts ...FormatOption) (string, error) {
+	serializable := eg.toSerialization(resolveGroupFormat(opts))
 
-	data, err := json.MarshalIndent(serializable, "", "  ")
+	data, err := jsonMarshaler().MarshalIndent(serializable, "", "  ")
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal ErrorGroup to JSON: %w", err)
 	}
@@ -261,9 +738,65 @@ func (eg *ErrorGroup) ToJSON() (string, error) {
 	return string(data), nil
 }
 
-// ToYAML converts the ErrorGroup to YAML format.
-func (eg *ErrorGroup) ToYAML() (string, error) {
-	serializable := eg.ToSerialization()
+// csvHeader lists the columns written by ToCSV.
+//
+//nolint:gochecknoglobals // read-only column list
+var csvHeader = []string{"index", "type", "severity", "message", "code", "http_status"}
+
+// ToCSV renders the group as RFC 4180 CSV, one row per error after a header
+// row: index (0-based), type, severity, message, code, http_status. Type and
+// severity come from the first ErrorContext in each error's chain, as for
+// FilterBySeverity. The message is the full Error() text, so causes are
+// summarized inline; code is the one attached with WithCode and http_status
+// the one attached with WithHTTPStatus, each empty when unset. Metadata is
+// omitted.
+func (eg *ErrorGroup) ToCSV() (string, error) {
+	eg.mu.RLock()
+	defer eg.mu.RUnlock()
+
+	var builder strings.Builder
+
+	w := csv.NewWriter(&builder)
+
+	err := w.Write(csvHeader)
+	if err != nil {
+		return "", fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for i, member := range eg.errors {
+		errType, severity := ErrorTypeUnknown, SeverityError
+		if ctx := chainErrorContext(member); ctx != nil {
+			errType, severity = ctx.Type, ctx.Severity
+		}
+
+		httpStatus := ""
+		if status := HTTPStatus(member); status != 0 {
+			httpStatus = strconv.Itoa(status)
+		}
+
+		err = w.Write([]string{
+			strconv.Itoa(i), errType.String(), severity.String(), member.Error(),
+			codeOf(member).String(), httpStatus,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to write CSV row %d: %w", i, err)
+		}
+	}
+
+	w.Flush()
+
+	err = w.Error()
+	if err != nil {
+		return "", fmt.Errorf("failed to flush CSV: %w", err)
+	}
+
+	return builder.String(), nil
+}
+
+// ToYAML converts the ErrorGroup to YAML format. Format options apply as
+// for ToJSON.
+func (eg *ErrorGroup) ToYAML(opts ...FormatOption) (string, error) {
+	serializable := eg.toSerialization(resolveGroupFormat(opts))
 
 	data, err := yaml.Marshal(serializable)
 	if err != nil {
@@ -277,7 +810,7 @@ func (eg *ErrorGroup) ToYAML() (string, error) {
 func (eg *ErrorGroup) MarshalJSON() ([]byte, error) {
 	serializable := eg.ToSerialization()
 
-	data, err := json.Marshal(serializable)
+	data, err := jsonMarshaler().Marshal(serializable)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal ErrorGroup to JSON: %w", err)
 	}
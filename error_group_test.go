@@ -1,8 +1,13 @@
 package ewrap
 
 import (
+	"context"
+	"encoding/csv"
 	"errors"
 	"fmt"
+	"net/http"
+	"slices"
+	"strings"
 	"sync"
 	"testing"
 )
@@ -90,6 +95,48 @@ func TestConcurrentPoolUsage(t *testing.T) {
 	wg.Wait()
 }
 
+func TestErrorGroupPoolStats(t *testing.T) {
+	t.Parallel()
+
+	pool := NewErrorGroupPool(exactCapacity)
+
+	if stats := pool.Stats(); stats != (PoolStats{}) {
+		t.Fatalf("expected zero stats for a fresh pool, got %+v", stats)
+	}
+
+	const cycles = 10
+
+	for range cycles {
+		eg := pool.Get()
+		eg.Add(errFirst)
+		eg.Release()
+	}
+
+	stats := pool.Stats()
+
+	if stats.Gets != cycles {
+		t.Errorf("Gets: got %d, want %d", stats.Gets, cycles)
+	}
+
+	if stats.Puts != cycles {
+		t.Errorf("Puts: got %d, want %d", stats.Puts, cycles)
+	}
+
+	if stats.Allocations < 1 || stats.Allocations > cycles {
+		t.Errorf("Allocations: got %d, want between 1 and %d", stats.Allocations, cycles)
+	}
+
+	if stats.Hits+stats.Allocations != stats.Gets {
+		t.Errorf("Hits (%d) + Allocations (%d) must equal Gets (%d)", stats.Hits, stats.Allocations, stats.Gets)
+	}
+
+	NewErrorGroup().Release() // unpooled release must not count
+
+	if got := pool.Stats().Puts; got != cycles {
+		t.Errorf("Puts after unpooled release: got %d, want %d", got, cycles)
+	}
+}
+
 func BenchmarkErrorGroupPool(b *testing.B) {
 	const sampleCount = 5
 
@@ -164,3 +211,602 @@ func TestErrorGroupJoin(t *testing.T) {
 		t.Fatal("expected nil when joining empty group")
 	}
 }
+
+// newSeverityGroup builds a group holding one error per severity level plus a
+// standard error, which is classified as SeverityError.
+func newSeverityGroup() *ErrorGroup {
+	ctx := context.Background()
+
+	eg := NewErrorGroup()
+	eg.Add(New("info", WithContext(ctx, ErrorTypeUnknown, SeverityInfo)))
+	eg.Add(New("warning", WithContext(ctx, ErrorTypeUnknown, SeverityWarning)))
+	eg.Add(New("error", WithContext(ctx, ErrorTypeUnknown, SeverityError)))
+	eg.Add(New("critical", WithContext(ctx, ErrorTypeUnknown, SeverityCritical)))
+	eg.Add(errStandard)
+
+	return eg
+}
+
+func TestErrorGroupFilterBySeverity(t *testing.T) {
+	t.Parallel()
+
+	eg := newSeverityGroup()
+
+	filtered := eg.FilterBySeverity(SeverityError)
+
+	got := filtered.Errors()
+	if len(got) != 3 {
+		t.Fatalf("expected 3 errors at or above SeverityError, got %d: %v", len(got), got)
+	}
+
+	if !slices.Contains(got, errStandard) {
+		t.Error("expected standard error to be kept as SeverityError")
+	}
+
+	if len(eg.Errors()) != 5 {
+		t.Error("filtering must not mutate the source group")
+	}
+
+	if eg.FilterBySeverity(SeverityInfo).Errors()[0] != eg.Errors()[0] {
+		t.Error("expected SeverityInfo filter to keep everything in order")
+	}
+}
+
+func TestErrorGroupHighestSeverity(t *testing.T) {
+	t.Parallel()
+
+	if got := newSeverityGroup().HighestSeverity(); got != SeverityCritical {
+		t.Errorf("expected %v, got %v", SeverityCritical, got)
+	}
+
+	eg := NewErrorGroup()
+	eg.Add(New("warning", WithContext(context.Background(), ErrorTypeUnknown, SeverityWarning)))
+
+	if got := eg.HighestSeverity(); got != SeverityWarning {
+		t.Errorf("expected %v, got %v", SeverityWarning, got)
+	}
+
+	eg.Add(errPlain)
+
+	if got := eg.HighestSeverity(); got != SeverityError {
+		t.Errorf("expected standard error to raise severity to %v, got %v", SeverityError, got)
+	}
+
+	if got := NewErrorGroup().HighestSeverity(); got != SeverityInfo {
+		t.Errorf("expected empty group to report %v, got %v", SeverityInfo, got)
+	}
+}
+
+func TestErrorGroupJoinWith(t *testing.T) {
+	t.Parallel()
+
+	eg := NewErrorGroup()
+	eg.Add(errFirst)
+	eg.Add(errSecond)
+	eg.Add(errFirst)
+
+	cases := []struct {
+		name  string
+		sep   string
+		dedup bool
+		want  string
+	}{
+		{"comma without dedup", ", ", false, "first, second, first"},
+		{"comma with dedup", ", ", true, "first, second"},
+		{"pipe with dedup", " | ", true, "first | second"},
+		{"newline without dedup", "\n", false, "first\nsecond\nfirst"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			joined := eg.JoinWith(tc.sep, tc.dedup)
+			if joined == nil {
+				t.Fatal("expected joined error")
+			}
+
+			if got := joined.Error(); got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+
+			if !errors.Is(joined, errFirst) || !errors.Is(joined, errSecond) {
+				t.Error("joined error does not unwrap to its members")
+			}
+		})
+	}
+
+	if NewErrorGroup().JoinWith(", ", true) != nil {
+		t.Error("expected nil when joining empty group")
+	}
+}
+
+func TestErrorGroupJoinWithDedupKeepsMembers(t *testing.T) {
+	t.Parallel()
+
+	eg := NewErrorGroup()
+	eg.Add(errSentinel)
+	eg.Add(errOtherSentinel)
+
+	joined := eg.JoinWith("; ", true)
+
+	if got := joined.Error(); got != msgSentinel {
+		t.Errorf("expected duplicate message to collapse, got %q", got)
+	}
+
+	if !errors.Is(joined, errOtherSentinel) {
+		t.Error("dedup must not hide members from errors.Is")
+	}
+}
+
+func TestErrorGroupEach(t *testing.T) {
+	t.Parallel()
+
+	eg := NewErrorGroup()
+	for i := range smallErrorCount {
+		eg.Add(fmt.Errorf("error %d", i))
+	}
+
+	t.Run("Full", func(t *testing.T) {
+		t.Parallel()
+
+		var seen []int
+
+		eg.Each(func(i int, err error) bool {
+			if err.Error() != fmt.Sprintf("error %d", i) {
+				t.Errorf("index %d: got %q", i, err)
+			}
+
+			seen = append(seen, i)
+
+			return true
+		})
+
+		if !slices.Equal(seen, []int{0, 1, 2, 3, 4}) {
+			t.Errorf("visited: got %v", seen)
+		}
+	})
+
+	t.Run("EarlyStop", func(t *testing.T) {
+		t.Parallel()
+
+		calls := 0
+
+		eg.Each(func(i int, _ error) bool {
+			calls++
+
+			return i < 1
+		})
+
+		if calls != 2 {
+			t.Errorf("calls: got %d, want 2", calls)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		t.Parallel()
+
+		NewErrorGroup().Each(func(int, error) bool {
+			t.Error("fn must not be called for an empty group")
+
+			return true
+		})
+	})
+}
+
+func TestErrorGroupAddAll(t *testing.T) {
+	t.Parallel()
+
+	errA := New(msgFirst)
+	errB := New(msgSecond)
+
+	eg := NewErrorGroup()
+	eg.Add(errA)
+	eg.AddAll(nil, errB, nil, errA)
+	eg.AddAll()
+
+	got := eg.Errors()
+	if want := []error{errA, errB, errA}; !slices.Equal(got, want) {
+		t.Errorf("Errors: got %v, want %v", got, want)
+	}
+}
+
+func TestErrorGroupFromErrors(t *testing.T) {
+	t.Parallel()
+
+	results := []error{nil, New(msgFirst), nil, nil, New(msgSecond)}
+
+	eg := ErrorGroupFromErrors(results...)
+	if n := len(eg.Errors()); n != 2 {
+		t.Errorf("count: got %d, want 2", n)
+	}
+
+	if ErrorGroupFromErrors(nil, nil).ErrorOrNil() != nil {
+		t.Error("a group built only from nils must be empty")
+	}
+}
+
+func TestErrorGroupMaxErrorsInMessage(t *testing.T) {
+	t.Parallel()
+
+	const total = 1000
+
+	eg := NewErrorGroup().WithMaxErrorsInMessage(3)
+	for i := range total {
+		eg.Add(fmt.Errorf("error %d", i))
+	}
+
+	msg := eg.Error()
+
+	want := "1000 errors occurred:\n1: error 0\n2: error 1\n3: error 2\n... and 997 more\n"
+	if msg != want {
+		t.Errorf("Error():\ngot  %q\nwant %q", msg, want)
+	}
+
+	if n := len(eg.Errors()); n != total {
+		t.Errorf("Errors() must keep every error: got %d", n)
+	}
+
+	if n := len(eg.ToSerialization().Errors); n != total {
+		t.Errorf("serialization must keep every error: got %d", n)
+	}
+
+	small := NewErrorGroup().WithMaxErrorsInMessage(3)
+	small.AddAll(errFirst, errOriginal)
+
+	if strings.Contains(small.Error(), "more") {
+		t.Errorf("groups within the cap must not be truncated: %q", small.Error())
+	}
+
+	if strings.Contains(eg.WithMaxErrorsInMessage(0).Error(), "more") {
+		t.Error("n <= 0 must remove the cap")
+	}
+}
+
+func TestErrorGroupToCSV(t *testing.T) {
+	t.Parallel()
+
+	eg := ErrorGroupFromErrors(
+		New(`bad "quoted", value`, WithType(ErrorTypeValidation), WithSeverity(SeverityWarning), WithHTTPStatus(http.StatusBadRequest)),
+		Wrap(errOriginal, "line one\nline two"),
+		fmt.Errorf("outer: %w", New(msgRoot, WithCode(codeNotFound))),
+	)
+
+	out, err := eg.ToCSV()
+	if err != nil {
+		t.Fatalf(unexpectedErrFn, err)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("ToCSV output is not valid CSV: %v\n%s", err, out)
+	}
+
+	want := [][]string{
+		{"index", "type", "severity", "message", "code", "http_status"},
+		{"0", "validation", "warning", `bad "quoted", value`, "", "400"},
+		{"1", "unknown", "error", "line one\nline two: " + msgOriginal, "", ""},
+		{"2", "unknown", "error", "outer: " + msgRoot, codeNotFound.String(), ""},
+	}
+
+	if len(records) != len(want) {
+		t.Fatalf("rows: got %d, want %d", len(records), len(want))
+	}
+
+	for i := range want {
+		if len(records[i]) != len(want[i]) {
+			t.Fatalf("row %d: got %d columns, want %d", i, len(records[i]), len(want[i]))
+		}
+
+		if !slices.Equal(records[i], want[i]) {
+			t.Errorf("row %d: got %q, want %q", i, records[i], want[i])
+		}
+	}
+}
+
+func TestErrorGroupSortBySeverity(t *testing.T) {
+	t.Parallel()
+
+	warnA := New("warn a", WithSeverity(SeverityWarning))
+	info := New("info", WithSeverity(SeverityInfo))
+	warnB := New("warn b", WithSeverity(SeverityWarning))
+	critical := New("critical", WithSeverity(SeverityCritical))
+
+	eg := ErrorGroupFromErrors(warnA, info, errOriginal, warnB, critical)
+	eg.SortBySeverity()
+
+	want := []error{critical, errOriginal, warnA, warnB, info}
+	if got := eg.Errors(); !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestErrorGroupSortByType(t *testing.T) {
+	t.Parallel()
+
+	netA := New("net a", WithType(ErrorTypeNetwork))
+	validation := New("validation", WithType(ErrorTypeValidation))
+	netB := New("net b", WithType(ErrorTypeNetwork))
+
+	eg := ErrorGroupFromErrors(netA, validation, errOriginal, netB)
+	eg.SortByType()
+
+	want := []error{errOriginal, validation, netA, netB}
+	if got := eg.Errors(); !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestErrorGroupWrapEach(t *testing.T) {
+	t.Parallel()
+
+	tagged := New(msgFirst).WithMetadata(msgKey, msgValue)
+	eg := ErrorGroupFromErrors(tagged, errSecond)
+
+	eg.WrapEach("processing batch 42", WithHTTPStatus(http.StatusBadGateway))
+
+	errs := eg.Errors()
+	for i, want := range []string{"processing batch 42: " + msgFirst, "processing batch 42: " + msgSecond} {
+		if got := errs[i].Error(); got != want {
+			t.Errorf("error %d: got %q, want %q", i, got, want)
+		}
+
+		if HTTPStatus(errs[i]) != http.StatusBadGateway {
+			t.Errorf("error %d: options were not applied", i)
+		}
+	}
+
+	var wrapped *Error
+	if !errors.As(errs[0], &wrapped) || wrapped.Unwrap() != tagged {
+		t.Fatal("expected the original error as the cause")
+	}
+
+	if val, ok := wrapped.GetMetadata(msgKey); !ok || val != msgValue {
+		t.Errorf("metadata lost through WrapEach, got %v, %v", val, ok)
+	}
+
+	if !errors.Is(eg.Join(), errSecond) {
+		t.Error("wrapped standard errors must stay reachable")
+	}
+}
+
+func TestErrorGroupAnyAll(t *testing.T) {
+	t.Parallel()
+
+	isValidation := func(err error) bool {
+		var e *Error
+
+		return errors.As(err, &e) && e.IsType(ErrorTypeValidation)
+	}
+
+	eg := ErrorGroupFromErrors(
+		New("bad email", WithType(ErrorTypeValidation), WithRetryable(false)),
+		New("timeout", WithType(ErrorTypeNetwork), WithRetryable(true)),
+	)
+
+	if !eg.Any(isValidation) {
+		t.Error("Any: expected a validation error")
+	}
+
+	if eg.All(IsRetryable) {
+		t.Error("All: not every error is retryable")
+	}
+
+	if eg.Any(func(error) bool { return false }) {
+		t.Error("Any: a false predicate must not match")
+	}
+
+	var seen int
+
+	eg.Any(func(error) bool {
+		seen++
+
+		return true
+	})
+
+	if seen != 1 {
+		t.Errorf("Any should stop at the first match, evaluated %d errors", seen)
+	}
+
+	retryable := ErrorGroupFromErrors(New(msgFirst, WithRetryable(true)), New(msgSecond, WithRetryable(true)))
+	if !retryable.All(IsRetryable) {
+		t.Error("All: expected every error to be retryable")
+	}
+
+	if !NewErrorGroup().All(IsRetryable) {
+		t.Error("All: an empty group is vacuously true")
+	}
+}
+
+func TestErrorGroupFlatten(t *testing.T) {
+	t.Parallel()
+
+	deepest := ErrorGroupFromErrors(errSentinel, errPlain)
+	middle := ErrorGroupFromErrors(errSecond, deepest)
+	eg := ErrorGroupFromErrors(errFirst, middle, errOriginal)
+
+	eg.Flatten()
+
+	want := []error{errFirst, errSecond, errSentinel, errPlain, errOriginal}
+	if got := eg.Errors(); !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	if len(middle.Errors()) != 2 || len(deepest.Errors()) != 2 {
+		t.Error("Flatten must not modify the nested groups")
+	}
+
+	cyclic := ErrorGroupFromErrors(errFirst)
+	cyclic.Add(ErrorGroupFromErrors(errSecond, cyclic))
+	cyclic.Flatten()
+
+	if got := cyclic.Errors(); !slices.Equal(got, []error{errFirst, errSecond}) {
+		t.Errorf("expected a self-containing group to be skipped, got %v", got)
+	}
+}
+
+func TestMultiErrorIs(t *testing.T) {
+	t.Parallel()
+
+	// buried hides the sentinel and a timeoutError behind layers of
+	// wrapping inside the second branch of a multi-cause structure.
+	buried := func() error {
+		return Wrap(fmt.Errorf("dialing: %w", Wrap(errSentinel, msgWrapped)), msgSecond)
+	}
+
+	group := func() *ErrorGroup {
+		return ErrorGroupFromErrors(errFirst, buried(), timeoutError{})
+	}
+
+	cases := map[string]error{
+		"group":                 group(),
+		"group via ErrorOrNil":  group().ErrorOrNil(),
+		"group Join":            group().Join(),
+		"group JoinWith":        group().JoinWith("; ", true),
+		"nested group":          ErrorGroupFromErrors(errOther, group()),
+		"wrapped group":         Wrap(group(), msgWrapped),
+		"group inside Join":     errors.Join(errOther, group()),
+		"Newf with several %w":  Newf("%w and %w and %w", errFirst, buried(), timeoutError{}),
+		"Wrap of errors.Join":   Wrap(errors.Join(errFirst, buried(), timeoutError{}), msgWrapped),
+		"group of Newf":         ErrorGroupFromErrors(errOther, Newf("%w, %w", errFirst, errors.Join(buried(), timeoutError{}))),
+		"Wrap of Newf of group": Wrap(Newf("batch: %w; %w", errOther, group()), msgWrapped),
+	}
+
+	for name, err := range cases {
+		if !errors.Is(err, errSentinel) {
+			t.Errorf("%s: errors.Is missed the buried sentinel", name)
+		}
+
+		if !errors.Is(err, errFirst) && !errors.Is(err, errOther) {
+			t.Errorf("%s: errors.Is missed the first branch", name)
+		}
+
+		var target timeoutError
+		if !errors.As(err, &target) {
+			t.Errorf("%s: errors.As missed the last branch", name)
+		}
+
+		if errors.Is(err, errOtherSentinel) {
+			t.Errorf("%s: errors.Is must compare identity, not text", name)
+		}
+	}
+}
+
+// sliceError is an error type whose values cannot be compared with ==.
+type sliceError []string
+
+func (e sliceError) Error() string { return strings.Join(e, ", ") }
+
+func TestWrapErrorGroupDoesNotAdoptAMember(t *testing.T) {
+	t.Parallel()
+
+	group := ErrorGroupFromErrors(
+		New(msgRoot, WithCode(codeNotFound), WithHTTPStatus(http.StatusNotFound)).
+			WithMetadata(msgKey, msgValue),
+		New(msgOriginal),
+	)
+
+	wrapped := Wrap(group, msgWrapped)
+	if _, ok := wrapped.GetMetadata(msgKey); ok {
+		t.Error("wrapping a group must not inherit a member's metadata")
+	}
+
+	if wrapped.Code() != "" || HTTPStatus(wrapped) != 0 {
+		t.Errorf("wrapping a group must not inherit a member's code or status: %q %d",
+			wrapped.Code(), HTTPStatus(wrapped))
+	}
+
+	if wrapped.depth != 1 {
+		t.Errorf("depth: got %d, want 1", wrapped.depth)
+	}
+
+	output := wrapped.ToErrorOutput()
+	if output.Cause == nil || output.Cause.Message != group.Error() || output.Cause.Metadata != nil {
+		t.Errorf("the group must serialize as itself, got %+v", output.Cause)
+	}
+
+	outer := ErrorGroupFromErrors(fmt.Errorf("batch: %w", group))
+
+	member := outer.ToSerialization().Errors[0]
+	if member.Type != "standard" || member.Metadata != nil {
+		t.Errorf("a wrapped group must not serialize as one of its members, got %+v", member)
+	}
+}
+
+func TestErrorGroupRemove(t *testing.T) {
+	t.Parallel()
+
+	eg := ErrorGroupFromErrors(errFirst, errSentinel, errSecond, errSentinel)
+
+	if !eg.Remove(errSentinel) {
+		t.Fatal("expected Remove to find the error by identity")
+	}
+
+	if got := eg.Errors(); !slices.Equal(got, []error{errFirst, errSecond, errSentinel}) {
+		t.Errorf("expected only the first match removed, got %v", got)
+	}
+
+	// errOtherSentinel has the same text but a different identity.
+	if !eg.Remove(errOtherSentinel) {
+		t.Fatal("expected Remove to fall back to message equality")
+	}
+
+	if got := eg.Errors(); !slices.Equal(got, []error{errFirst, errSecond}) {
+		t.Errorf("expected the sentinel removed by message, got %v", got)
+	}
+
+	if eg.Remove(errPlain) || eg.Remove(nil) {
+		t.Error("Remove must report false when nothing matches")
+	}
+
+	// Uncomparable error types must not make the identity check panic.
+	eg.Add(sliceError{msgTest})
+
+	if !eg.Remove(sliceError{msgTest}) {
+		t.Error("expected an uncomparable error to be removed by message")
+	}
+
+	eg.AddAll(New(msgTest), New(msgTest))
+
+	isEwrap := func(err error) bool {
+		_, ok := err.(*Error)
+
+		return ok
+	}
+
+	if removed := eg.RemoveFunc(isEwrap); removed != 2 {
+		t.Errorf("expected RemoveFunc to remove 2 errors, got %d", removed)
+	}
+
+	if got := eg.Errors(); !slices.Equal(got, []error{errFirst, errSecond}) {
+		t.Errorf("RemoveFunc removed the wrong errors, got %v", got)
+	}
+}
+
+func TestErrorGroupRemoveConcurrent(t *testing.T) {
+	t.Parallel()
+
+	eg := NewErrorGroup()
+
+	var wg sync.WaitGroup
+
+	for range concurrencyLimit {
+		wg.Go(func() {
+			eg.Add(errSentinel)
+		})
+		wg.Go(func() {
+			eg.Add(errPlain)
+			eg.Remove(errPlain)
+		})
+	}
+
+	wg.Wait()
+
+	if removed := eg.RemoveFunc(func(err error) bool { return err == errPlain }); removed != 0 {
+		t.Errorf("every errPlain should have been removed, %d left", removed)
+	}
+
+	if got := len(eg.Errors()); got != concurrencyLimit {
+		t.Errorf("expected %d errors after concurrent removal, got %d", concurrencyLimit, got)
+	}
+}
@@ -1,12 +1,16 @@
 package ewrap
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"maps"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 const (
@@ -18,8 +22,38 @@ const (
 	// the captured stack starts at the user's call site rather than inside
 	// ewrap. Tuned for direct calls to New / Wrap / Newf / Wrapf.
 	callerSkipNew = 3
+	// defaultMessageSeparator joins a layer's message to its cause's text.
+	defaultMessageSeparator = ": "
+	// defaultMaxWrapDepth bounds how many ewrap layers Wrap will nest before
+	// collapsing further wraps into the top layer.
+	defaultMaxWrapDepth = 64
+	// maxUnwrapLayers caps how many links UnwrapAll follows, so a cyclic
+	// chain built from custom Unwrap methods cannot loop forever.
+	maxUnwrapLayers = 1024
 )
 
+//nolint:gochecknoglobals // package-wide rendering policy, swapped atomically
+var messageSeparator atomic.Value
+
+// SetMessageSeparator changes the text placed between a layer's message and
+// its cause in Error() and SafeError(); the default is ": ". Only the string
+// form changes: errors.Is, errors.As and Unwrap are unaffected. Because
+// Error() caches its result, configure the separator once at startup,
+// before errors are rendered.
+func SetMessageSeparator(sep string) {
+	messageSeparator.Store(sep)
+}
+
+// currentMessageSeparator returns the separator set by SetMessageSeparator,
+// or the default.
+func currentMessageSeparator() string {
+	if sep, ok := messageSeparator.Load().(string); ok {
+		return sep
+	}
+
+	return defaultMessageSeparator
+}
+
 // Error represents a custom error type with stack trace and structured metadata.
 //
 // Fields populated by package-provided options (ErrorContext, RecoverySuggestion,
@@ -37,46 +71,91 @@ type Error struct {
 	logger       Logger
 	observer     Observer
 
+	// ownsContext reports whether errorContext was allocated or copied by
+	// this error and may be mutated in place. A context inherited from the
+	// cause or supplied by the caller is copied before the first mutation.
+	ownsContext bool
+
+	// code is the machine-readable code attached via WithCode; empty means
+	// unset.
+	code Code
+	// tags are the labels attached via WithTags, deduplicated and in the
+	// order first added. Never appended to after construction.
+	tags []string
 	// httpStatus carries an HTTP status code attached via WithHTTPStatus.
 	// Zero means unset.
 	httpStatus int
 	// retryable holds an explicit retry classification (tri-state via pointer:
 	// nil = not classified, &true / &false = explicit).
 	retryable *bool
+	// temporary overrides the type-derived answer of Temporary (tri-state
+	// via pointer like retryable). Set via WithTemporary.
+	temporary *bool
 	// safeMsg is a redacted variant of msg returned by SafeError when set.
 	safeMsg string
+	// fingerprint selects the inputs to Hash; zero means
+	// DefaultFingerprintFields. Set via WithFingerprintFields.
+	fingerprint FingerprintField
+
+	// callerSkip is the number of caller frames, beyond ewrap's own, hidden
+	// from the stack trace and from ErrorContext locations. Set via
+	// WithCallerSkip.
+	callerSkip int
+
+	// depth counts the ewrap wrap layers at and below this error; New sets
+	// 0. It drives the SetMaxWrapDepth guard.
+	depth int
+
+	// droppedMetadata counts metadata entries rejected by the
+	// SetMaxMetadataEntries cap. Guarded by mu.
+	droppedMetadata int
+
+	// createdAt records when the error was constructed. Serialization derives
+	// the output timestamp from it rather than from the time of formatting.
+	createdAt time.Time
 
 	// fullMsg is set when msg already includes the cause text (e.g. constructed
 	// via Newf with %w). When true, Error() returns msg verbatim.
 	fullMsg bool
 
-	// mu protects metadata mutation and retry mutation. Cached strings use
-	// sync.Once so they need no separate lock.
+	// mu protects metadata mutation, retry mutation and SetCause. Cached
+	// strings use sync.Once so they need no separate lock.
 	mu sync.RWMutex
 
-	// Cached lazy outputs. errOnce/errStr cache Error(); stackOnce/stackStr
-	// cache the formatted stack trace.
-	errOnce   sync.Once
-	errStr    string
+	// Cached lazy outputs. errText caches Error() and is swapped out by
+	// SetCause; stackOnce/stackStr cache the formatted stack trace.
+	errText   atomic.Pointer[errorText]
 	stackOnce sync.Once
 	stackStr  string
 }
 
+// errorText is the cached result of Error().
+type errorText struct {
+	once sync.Once
+	text string
+}
+
 // Option defines the signature for configuration options.
 type Option func(*Error)
 
-// WithLogger sets a logger for the error.
+// WithLogger sets a logger for the error, overriding any inherited or
+// package-default logger. Passing nil installs NoopLogger, which silences
+// logging for the error even when a default logger is configured.
 func WithLogger(log Logger) Option {
 	return func(err *Error) {
-		err.logger = log
+		if log == nil {
+			err.logger = NoopLogger{}
 
-		if log != nil {
-			log.Debug(
-				"error created",
-				"message", err.msg,
-				"stack", err.Stack(),
-			)
+			return
 		}
+
+		err.logger = log
+
+		log.Debug(
+			"error created",
+			"message", err.msg,
+			"stack", err.Stack(),
+		)
 	}
 }
 
@@ -103,11 +182,106 @@ func WithStackDepth(depth int) Option {
 	}
 }
 
+// WithCallerSkip hides the first skip caller frames (beyond ewrap's own) from
+// the stack trace and from the File/Line recorded by WithContext. Use it
+// when errors are built inside thin helpers so traces point at the helper's
+// caller instead. It must precede WithContext in the option list to affect
+// the recorded location; the stack trace honors it regardless of order.
+// Negative values are ignored.
+func WithCallerSkip(skip int) Option {
+	return func(err *Error) {
+		if skip > 0 {
+			err.callerSkip = skip
+		}
+	}
+}
+
+// WithFreshMetadata starts a wrapper with empty metadata instead of a clone
+// of the cause's, for callers who want each layer's metadata kept separate.
+// Wrap inherits by default. The cause keeps its own metadata either way.
+func WithFreshMetadata() Option {
+	return func(err *Error) {
+		err.metadata = nil
+	}
+}
+
+// WithInitialMetadataCapacity allocates the metadata map with room for n
+// entries, so errors that will carry many keys skip the rehashing of a
+// growing map. Metadata inherited by Wrap is kept. Place it after
+// WithFreshMetadata, which would otherwise discard the map. n <= 0 is
+// ignored.
+func WithInitialMetadataCapacity(n int) Option {
+	return func(err *Error) {
+		if n <= 0 || n <= len(err.metadata) {
+			return
+		}
+
+		sized := make(map[string]any, n)
+		maps.Copy(sized, err.metadata)
+		err.metadata = sized
+	}
+}
+
+//nolint:gochecknoglobals // package-wide guard, swapped atomically
+var maxWrapDepth atomic.Int64
+
+// SetMaxWrapDepth bounds how many ewrap layers Wrap nests (64 by default).
+// Once a chain reaches the limit, further wraps collapse into the top layer:
+// the new message is joined onto the top layer's message and the result
+// takes its place, so Error() keeps every message and errors.Is still finds
+// the root while the chain stops growing. 0 (or a negative value) means
+// unlimited. The guard applies when the wrapped error is itself an *Error.
+func SetMaxWrapDepth(n int) {
+	if n <= 0 {
+		maxWrapDepth.Store(-1)
+
+		return
+	}
+
+	maxWrapDepth.Store(int64(n))
+}
+
+// currentMaxWrapDepth returns the configured limit, or 0 for unlimited.
+func currentMaxWrapDepth() int {
+	switch limit := maxWrapDepth.Load(); {
+	case limit == 0:
+		return defaultMaxWrapDepth
+	case limit < 0:
+		return 0
+	default:
+		return int(limit)
+	}
+}
+
+//nolint:gochecknoglobals // package-wide guard, swapped atomically
+var maxMetadataEntries atomic.Int64
+
+// SetMaxMetadataEntries caps how many metadata keys a single error holds.
+// Once an error reaches the cap, WithMetadata and WithMetadataMap silently
+// drop new keys; overwriting an existing key still works. Each dropped entry
+// is counted (see DroppedMetadata) and reported to the error's observer if
+// it implements WarningObserver. 0 (the default) or a negative value means
+// unlimited.
+func SetMaxMetadataEntries(n int) {
+	maxMetadataEntries.Store(int64(max(n, 0)))
+}
+
 // New creates a new Error with a stack trace and applies the provided options.
 func New(msg string, opts ...Option) *Error {
 	return newAt(callerSkipNew, msg, opts...)
 }
 
+// NewWithCause creates an error with message msg caused by cause. It is Wrap
+// with the arguments in constructor order, except that a nil cause yields a
+// cause-less error as New does, where Wrap would return nil.
+func NewWithCause(msg string, cause error, opts ...Option) *Error {
+	if cause == nil {
+		return newAt(callerSkipNew, msg, opts...)
+	}
+
+	return wrapAt(callerSkipNew, cause, msg, opts...)
+}
+
 // NewSkip is like New but skips an additional frames stack frames so callers
 // wrapping New in a helper see their own location captured.
 func NewSkip(skip int, msg string, opts ...Option) *Error {
@@ -116,8 +290,10 @@ func NewSkip(skip int, msg string, opts ...Option) *Error {
 
 func newAt(skip int, msg string, opts ...Option) *Error {
 	err := &Error{
-		msg:   msg,
-		stack: capturePCs(skip, defaultStackDepth),
+		msg:       msg,
+		stack:     capturePCs(skip, defaultStackDepth),
+		createdAt: currentTime(),
+		logger:    currentDefaultLogger(),
 	}
 
 	for _, opt := range opts {
@@ -132,6 +308,8 @@ func newAt(skip int, msg string, opts ...Option) *Error {
 // If format contains the %w verb, the matching argument is preserved as the
 // error's cause so that errors.Is/As walk through it. The resulting Error
 // behaves like fmt.Errorf with respect to message text and unwrap chain.
+// As with Wrap, a cause with no *Error in its chain is run through the
+// classifiers (see RegisterClassifier) to infer a type and severity.
 func Newf(format string, args ...any) *Error {
 	return newfAt(callerSkipNew, format, args...)
 }
@@ -149,17 +327,24 @@ func newfAt(skip int, format string, args ...any) *Error {
 	if u, ok := formatted.(interface{ Unwrap() error }); ok {
 		cause = u.Unwrap()
 	} else if u, ok := formatted.(interface{ Unwrap() []error }); ok {
-		if causes := u.Unwrap(); len(causes) > 0 {
-			cause = causes[0]
-		}
+		// Several %w verbs: keep every operand reachable, as fmt.Errorf does.
+		cause = errors.Join(u.Unwrap()...)
 	}
 
-	return &Error{
-		msg:     formatted.Error(),
-		cause:   cause,
-		stack:   capturePCs(skip+1, defaultStackDepth),
-		fullMsg: true,
+	err := &Error{
+		msg:       formatted.Error(),
+		cause:     cause,
+		stack:     capturePCs(skip+1, defaultStackDepth),
+		createdAt: currentTime(),
+		logger:    currentDefaultLogger(),
+		fullMsg:   true,
 	}
+
+	if _, ok := chainError(cause); cause != nil && !ok {
+		err.withInferredContext()
+	}
+
+	return err
 }
 
 // Wrap wraps an existing error with additional context, capturing a stack
@@ -184,13 +369,16 @@ func wrapAt(skip int, err error, msg string, opts ...Option) *Error {
 	}
 
 	wrapped := &Error{
-		msg:   msg,
-		cause: err,
-		stack: capturePCs(skip, defaultStackDepth),
+		msg:       msg,
+		cause:     err,
+		stack:     capturePCs(skip, defaultStackDepth),
+		createdAt: currentTime(),
+		logger:    currentDefaultLogger(),
 	}
 
-	var inner *Error
-	if errors.As(err, &inner) {
+	var inferred *ErrorContext
+
+	if inner, ok := chainError(err); ok {
 		inner.mu.RLock()
 
 		if len(inner.metadata) > 0 {
@@ -201,19 +389,62 @@ func wrapAt(skip int, err error, msg string, opts ...Option) *Error {
 		wrapped.recovery = inner.recovery
 		wrapped.retry = inner.retry
 		wrapped.observer = inner.observer
-		wrapped.logger = inner.logger
+
+		if inner.logger != nil {
+			wrapped.logger = inner.logger
+		}
+
+		wrapped.code = inner.code
+		// Cloned so WithTags on the wrapper cannot write into the cause.
+		wrapped.tags = slices.Clone(inner.tags)
 		wrapped.httpStatus = inner.httpStatus
 		wrapped.retryable = inner.retryable
+		wrapped.temporary = inner.temporary
+		wrapped.fingerprint = inner.fingerprint
+		wrapped.depth = inner.depth + 1
 		inner.mu.RUnlock()
+	} else {
+		wrapped.depth = 1
+		inferred = wrapped.withInferredContext()
+	}
+
+	if limit := currentMaxWrapDepth(); limit > 0 && wrapped.depth > limit {
+		if top, ok := err.(*Error); ok {
+			wrapped.collapseInto(top)
+		}
 	}
 
 	for _, opt := range opts {
 		opt(wrapped)
 	}
 
+	wrapped.locateInferredContext(inferred)
+
 	return wrapped
 }
 
+// chainError returns the first *Error on err's single-cause chain. Unlike
+// errors.As it does not descend into multi-errors such as ErrorGroup or
+// errors.Join, whose members are unrelated to the error wrapping them.
+func chainError(err error) (*Error, bool) {
+	for cur := err; cur != nil; cur = errors.Unwrap(cur) {
+		if e, ok := cur.(*Error); ok {
+			return e, true
+		}
+	}
+
+	return nil, false
+}
+
+// collapseInto makes e stand in for top instead of wrapping it: e's message
+// is prefixed onto top's and e adopts top's cause, keeping chain depth flat.
+func (e *Error) collapseInto(top *Error) {
+	e.msg = e.msg + currentMessageSeparator() + top.msg
+	e.cause = top.cause
+	e.fullMsg = top.fullMsg
+	e.depth = top.depth
+}
+
 // Wrapf wraps an error with a formatted message.
 func Wrapf(err error, format string, args ...any) *Error {
 	if err == nil {
@@ -224,25 +455,193 @@ func Wrapf(err error, format string, args ...any) *Error {
 }
 
 // Error implements the error interface. The result is computed once on first
-// call and cached; subsequent calls are lock-free reads.
+// call and cached until SetCause replaces the cause.
 func (e *Error) Error() string {
-	e.errOnce.Do(func() {
+	cached := e.errText.Load()
+	if cached == nil {
+		e.errText.CompareAndSwap(nil, &errorText{})
+		cached = e.errText.Load()
+	}
+
+	cached.once.Do(func() {
+		cause := e.Cause()
+
 		switch {
-		case e.fullMsg, e.cause == nil:
-			e.errStr = e.msg
+		case e.fullMsg, cause == nil:
+			cached.text = e.msg
 		default:
-			e.errStr = e.msg + ": " + e.cause.Error()
+			cached.text = e.msg + currentMessageSeparator() + cause.Error()
 		}
 	})
 
-	return e.errStr
+	return cached.text
 }
 
 // Cause returns the underlying cause of the error.
 func (e *Error) Cause() error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
 	return e.cause
 }
 
+// SetCause replaces the error's cause in place, keeping its message,
+// metadata, stack and other attributes, e.g. to substitute a sanitized cause
+// before an error crosses a trust boundary. It returns e. The swap and the
+// reset of the cached Error() text happen under the error's lock, so it is
+// safe to call while other goroutines read the error. Wrappers that already
+// rendered their own Error() keep the old text. Errors from Newf with %w
+// embed the cause text in their message, and that text is not rewritten.
+func (e *Error) SetCause(cause error) *Error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.cause = cause
+
+	switch inner, ok := chainError(cause); {
+	case cause == nil:
+		e.depth = 0
+	case ok:
+		e.depth = inner.depth + 1
+	default:
+		e.depth = 1
+	}
+
+	e.errText.Store(nil)
+
+	return e
+}
+
+// Causes returns the message of each layer of the chain, from this error
+// inward. Walking stops at the first cause that is not an *Error, whose full
+// Error() text is appended as the final entry.
+func (e *Error) Causes() []string {
+	var causes []string
+
+	var cur error = e
+	for cur != nil {
+		layer, ok := cur.(*Error)
+		if !ok {
+			causes = append(causes, cur.Error())
+
+			break
+		}
+
+		causes = append(causes, layer.msg)
+		cur = layer.cause
+	}
+
+	return causes
+}
+
+// UnwrapAll returns every error in the chain, from this error inward,
+// following errors.Unwrap through *Error layers and standard wrappers alike.
+// The innermost error is the last entry. At most 1024 links are followed, so
+// a cyclic chain is cut short instead of looping forever.
+func (e *Error) UnwrapAll() []error {
+	var chain []error
+
+	var cur error = e
+	for cur != nil && len(chain) < maxUnwrapLayers {
+		chain = append(chain, cur)
+		cur = errors.Unwrap(cur)
+	}
+
+	return chain
+}
+
+// Flatten returns a new, cause-less *Error that collapses the whole chain
+// into one layer, for emitting a single concise log line. Its message is the
+// full Error() text and it keeps this error's stack, context, recovery,
+// retry, logger, observer and classification. Metadata from every *Error in
+// the chain is merged with outer layers taking precedence: a key set on an
+// inner layer never overrides the same key on an outer one.
+func (e *Error) Flatten() *Error {
+	e.mu.RLock()
+	metadata := maps.Clone(e.metadata)
+	e.mu.RUnlock()
+
+	for cur := e.cause; cur != nil; cur = errors.Unwrap(cur) {
+		layer, ok := cur.(*Error)
+		if !ok {
+			continue
+		}
+
+		layer.mu.RLock()
+
+		for key, val := range layer.metadata {
+			if _, exists := metadata[key]; exists {
+				continue
+			}
+
+			if metadata == nil {
+				metadata = make(map[string]any, len(layer.metadata))
+			}
+
+			metadata[key] = val
+		}
+
+		layer.mu.RUnlock()
+	}
+
+	return &Error{
+		msg:          e.Error(),
+		stack:        e.stack,
+		metadata:     metadata,
+		errorContext: e.errorContext,
+		recovery:     e.recovery,
+		retry:        e.retry,
+		logger:       e.logger,
+		observer:     e.observer,
+		code:         e.code,
+		tags:         slices.Clone(e.tags),
+		httpStatus:   e.httpStatus,
+		retryable:    e.retryable,
+		temporary:    e.temporary,
+		safeMsg:      e.SafeError(),
+		fingerprint:  e.fingerprint,
+		callerSkip:   e.callerSkip,
+		createdAt:    e.createdAt,
+	}
+}
+
+// Annotate returns a copy of the error whose message has "; extra"
+// appended. Unlike Wrap it adds no chain layer and captures no stack: the
+// copy keeps this error's cause, stack, context and every other attribute,
+// so it is the cheap choice for tacking on a detail. The receiver is left
+// untouched, keeping errors that are shared or already rendered stable. A
+// message set via WithSafeMessage is kept as-is, so the detail does not leak
+// into SafeError.
+func (e *Error) Annotate(extra string) *Error {
+	e.mu.RLock()
+	metadata := maps.Clone(e.metadata)
+	retry := e.retry
+	e.mu.RUnlock()
+
+	return &Error{
+		msg:          e.msg + "; " + extra,
+		cause:        e.cause,
+		stack:        e.stack,
+		metadata:     metadata,
+		errorContext: e.errorContext,
+		recovery:     e.recovery,
+		retry:        retry,
+		logger:       e.logger,
+		observer:     e.observer,
+		code:         e.code,
+		tags:         slices.Clone(e.tags),
+		httpStatus:   e.httpStatus,
+		retryable:    e.retryable,
+		temporary:    e.temporary,
+		safeMsg:      e.safeMsg,
+		fingerprint:  e.fingerprint,
+		fullMsg:      e.fullMsg,
+		callerSkip:   e.callerSkip,
+		depth:        e.depth,
+		createdAt:    e.createdAt,
+	}
+}
+
 // WithMetadata adds metadata to the error.
 //
 // The key namespace is reserved for user data; package-managed values (error
@@ -254,10 +653,16 @@ func (e *Error) WithMetadata(key string, value any) *Error {
 		e.metadata = make(map[string]any)
 	}
 
-	e.metadata[key] = value
+	added := e.setMetadataLocked(key, value, int(maxMetadataEntries.Load()))
 	log := e.logger
 	e.mu.Unlock()
 
+	if !added {
+		e.warnMetadataDropped(1)
+
+		return e
+	}
+
 	if log != nil {
 		log.Debug(
 			"metadata added",
@@ -270,9 +675,121 @@ func (e *Error) WithMetadata(key string, value any) *Error {
 	return e
 }
 
+// setMetadataLocked stores value under key unless that would push a new key
+// past limit (0 means unlimited), in which case the entry is counted as
+// dropped. It reports whether the entry was stored. e.mu must be held.
+func (e *Error) setMetadataLocked(key string, value any, limit int) bool {
+	if _, exists := e.metadata[key]; !exists && limit > 0 && len(e.metadata) >= limit {
+		e.droppedMetadata++
+
+		return false
+	}
+
+	e.metadata[key] = value
+
+	return true
+}
+
+// warnMetadataDropped reports dropped metadata entries to the error's
+// observer, if it implements WarningObserver.
+func (e *Error) warnMetadataDropped(n int) {
+	if w, ok := e.observer.(WarningObserver); ok {
+		w.RecordWarning(fmt.Sprintf("ewrap: metadata cap reached, dropped %d entries for %s", n, e.msg))
+	}
+}
+
+// DroppedMetadata returns how many metadata entries this error rejected
+// because of the SetMaxMetadataEntries cap.
+func (e *Error) DroppedMetadata() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.droppedMetadata
+}
+
+// WithMetadataFunc stores fn under key as lazily computed metadata, for
+// values that are expensive to build and only needed if the error is
+// actually logged or serialized. fn runs at most once, on the first read via
+// GetMetadata, Metadata, Log or serialization, and the result is reused from
+// then on, including by wrappers that inherited the key. fn runs under the
+// error's read lock, so it must not call back into the same error.
+func (e *Error) WithMetadataFunc(key string, fn func() any) *Error {
+	return e.WithMetadata(key, &lazyMetadata{fn: fn})
+}
+
+// lazyMetadata is the metadata value stored by WithMetadataFunc.
+type lazyMetadata struct {
+	once sync.Once
+	fn   func() any
+	val  any
+}
+
+// resolveMetadata returns val, evaluating it first if it was stored by
+// WithMetadataFunc.
+func resolveMetadata(val any) any {
+	lazy, ok := val.(*lazyMetadata)
+	if !ok {
+		return val
+	}
+
+	lazy.once.Do(func() {
+		if lazy.fn != nil {
+			lazy.val = lazy.fn()
+		}
+	})
+
+	return lazy.val
+}
+
+// WithMetadataMap copies every entry of m into the error's metadata under a
+// single lock acquisition, overwriting existing keys. It is the bulk form of
+// WithMetadata; no keys are reserved since package-managed values live in
+// dedicated fields.
+func (e *Error) WithMetadataMap(m map[string]any) *Error {
+	if len(m) == 0 {
+		return e
+	}
+
+	e.mu.Lock()
+
+	if e.metadata == nil {
+		e.metadata = make(map[string]any, len(m))
+	}
+
+	dropped := 0
+
+	if limit := int(maxMetadataEntries.Load()); limit > 0 {
+		for key, value := range m {
+			if !e.setMetadataLocked(key, value, limit) {
+				dropped++
+			}
+		}
+	} else {
+		maps.Copy(e.metadata, m)
+	}
+
+	log := e.logger
+	e.mu.Unlock()
+
+	if dropped > 0 {
+		e.warnMetadataDropped(dropped)
+	}
+
+	if log != nil {
+		log.Debug(
+			"metadata added",
+			"count", len(m),
+			"error", e.msg,
+		)
+	}
+
+	return e
+}
+
 // WithContext attaches an existing ErrorContext to the error.
 func (e *Error) WithContext(ctx *ErrorContext) *Error {
 	e.errorContext = ctx
+	e.ownsContext = false
 
 	if e.logger != nil {
 		e.logger.Debug(
@@ -312,7 +829,7 @@ func (e *Error) GetMetadata(key string) (any, bool) {
 
 	val, ok := e.metadata[key]
 
-	return val, ok
+	return resolveMetadata(val), ok
 }
 
 // GetMetadataValue retrieves user-defined metadata and casts it to type T.
@@ -327,7 +844,7 @@ func GetMetadataValue[T any](e *Error, key string) (T, bool) {
 		return zero, false
 	}
 
-	typedVal, ok := val.(T)
+	typedVal, ok := resolveMetadata(val).(T)
 	if !ok {
 		return zero, false
 	}
@@ -335,6 +852,60 @@ func GetMetadataValue[T any](e *Error, key string) (T, bool) {
 	return typedVal, true
 }
 
+// RemoveMetadata deletes key from the error's metadata. Removing a missing
+// key is a no-op.
+func (e *Error) RemoveMetadata(key string) *Error {
+	e.mu.Lock()
+	delete(e.metadata, key)
+	e.mu.Unlock()
+
+	return e
+}
+
+// Metadata returns a snapshot copy of the user-defined metadata. Mutating
+// the returned map does not affect the error. Package-managed values (error
+// context, recovery suggestion, retry info) are never included; use their
+// dedicated accessors instead. Returns nil when no metadata is set.
+func (e *Error) Metadata() map[string]any {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if len(e.metadata) == 0 {
+		return nil
+	}
+
+	snapshot := make(map[string]any, len(e.metadata))
+	for key, val := range e.metadata {
+		snapshot[key] = resolveMetadata(val)
+	}
+
+	return snapshot
+}
+
+// GetString retrieves a string metadata value. ok is false when the key is
+// missing or holds a value of another type.
+func (e *Error) GetString(key string) (string, bool) {
+	return GetMetadataValue[string](e, key)
+}
+
+// GetInt retrieves an int metadata value. ok is false when the key is
+// missing or holds a value of another type; no numeric conversion is done.
+func (e *Error) GetInt(key string) (int, bool) {
+	return GetMetadataValue[int](e, key)
+}
+
+// GetBool retrieves a bool metadata value. ok is false when the key is
+// missing or holds a value of another type.
+func (e *Error) GetBool(key string) (bool, bool) {
+	return GetMetadataValue[bool](e, key)
+}
+
+// GetTime retrieves a time.Time metadata value. ok is false when the key is
+// missing or holds a value of another type.
+func (e *Error) GetTime(key string) (time.Time, bool) {
+	return GetMetadataValue[time.Time](e, key)
+}
+
 // GetErrorContext returns the structured error context, or nil if none was set.
 func (e *Error) GetErrorContext() *ErrorContext {
 	return e.errorContext
@@ -359,38 +930,60 @@ func (e *Error) Stack() string {
 			return
 		}
 
-		var builder strings.Builder
-
-		frames := runtime.CallersFrames(e.stack)
-
-		for {
-			frame, more := frames.Next()
-			if !isInternalFrame(frame) {
-				_, _ = fmt.Fprintf(&builder, "%s:%d - %s\n", frame.File, frame.Line, frame.Function)
-			}
-
-			if !more {
-				break
-			}
-		}
-
-		e.stackStr = builder.String()
+		e.stackStr = e.StackTrace().String()
 	})
 
 	return e.stackStr
 }
 
-// Log logs the error using the configured logger.
+// Log logs the error using the configured logger. The record is assembled
+// under a single read lock, so it is consistent with concurrent metadata
+// writes; the observer and logger are called after the lock is released.
+// Errors below the SetLogSeverityThreshold level, and records dropped by
+// SetLogSampleRate, reach the observer only.
 func (e *Error) Log() {
-	if e.observer != nil {
-		e.observer.RecordError(e.msg)
+	e.logWith(context.Background())
+}
+
+// LogContext is Log with request-scoped fields, such as a trace ID or
+// tenant, read from ctx at log time using the keys configured with
+// SetLogContextKeys. The fields go into this log record only and are not
+// stored on the error.
+func (e *Error) LogContext(ctx context.Context) {
+	e.logWith(ctx)
+}
+
+// logWith implements Log and LogContext.
+func (e *Error) logWith(ctx context.Context) {
+	skipLogger := belowLogThreshold(severityOf(e)) || sampledOut()
+
+	e.mu.RLock()
+	observer, logger := e.observer, e.logger
+
+	if skipLogger {
+		logger = nil
 	}
 
-	if e.logger == nil {
-		return
+	var logData []any
+	if logger != nil {
+		logData = e.logDataLocked()
 	}
 
-	e.mu.RLock()
+	e.mu.RUnlock()
+
+	if observer != nil {
+		observer.RecordError(e.msg)
+	}
+
+	if logger != nil {
+		logData = appendContextLogFields(logData, ctx)
+		logger.Error("error occurred", logData...)
+	}
+}
+
+// logDataLocked builds the key/value pairs emitted by Log. The caller must
+// hold e.mu.
+func (e *Error) logDataLocked() []any {
 	logData := make([]any, 0, len(e.metadata)*2+baseLogDataSize)
 	logData = append(logData, "error", e.msg)
 
@@ -401,16 +994,18 @@ func (e *Error) Log() {
 	logData = append(logData, "stack", e.Stack())
 
 	for key, val := range e.metadata {
-		logData = append(logData, key, val)
+		logData = append(logData, key, resolveMetadata(val))
 	}
 
-	e.mu.RUnlock()
+	if e.errorContext != nil {
+		logData = appendErrorContext(logData, e.errorContext)
+	}
 
 	if e.recovery != nil {
 		logData = appendRecoverySuggestion(logData, e.recovery)
 	}
 
-	e.logger.Error("error occurred", logData...)
+	return logData
 }
 
 // CaptureStack captures the current stack trace at the call site using the
@@ -420,24 +1015,28 @@ func CaptureStack() []uintptr {
 }
 
 // capturePCs returns the program counters of the current call stack starting
-// skip frames up. The slice is sized to depth so callers with shallow stacks
-// don't carry empty trailing slots.
+// skip frames up, as counted by runtime.Callers, using the capturer installed
+// by SetStackCapturer. At most depth frames are kept.
 func capturePCs(skip, depth int) []uintptr {
 	if depth <= 0 {
 		return nil
 	}
 
-	pcs := make([]uintptr, depth)
-	n := runtime.Callers(skip, pcs)
+	// The capturer counts from capturePCs, one frame above runtime.Callers.
+	pcs := currentStackCapturer(depth).Capture(skip - 1)
+	if len(pcs) > depth {
+		pcs = pcs[:depth]
+	}
 
-	return pcs[:n]
+	return pcs
 }
 
 // Unwrap provides compatibility with Go 1.13 error chains. errors.Is and
-// errors.As walk the chain via this method; the package-level Is method is
-// intentionally not implemented so the stdlib semantics apply unchanged.
+// errors.As walk the chain via this method. (*Error).Is adds code matching
+// for code-only targets on top; every other target keeps the stdlib
+// identity semantics.
 func (e *Error) Unwrap() error {
-	return e.cause
+	return e.Cause()
 }
 
 // isInternalFrame returns true for frames the user shouldn't see in a stack
@@ -460,6 +1059,26 @@ func isInternalFrame(frame runtime.Frame) bool {
 	return true
 }
 
+// appendErrorContext flattens an ErrorContext into individual log fields,
+// so loggers see plain strings rather than a struct pointer. Empty
+// identifiers are left out.
+func appendErrorContext(logData []any, ctx *ErrorContext) []any {
+	logData = append(logData, "type", ctx.Type.String(), "severity", ctx.Severity.String())
+
+	for _, field := range [...]struct{ key, val string }{
+		{"request_id", ctx.RequestID},
+		{"component", ctx.Component},
+		{"operation", ctx.Operation},
+		{"user", ctx.User},
+	} {
+		if field.val != "" {
+			logData = append(logData, field.key, field.val)
+		}
+	}
+
+	return logData
+}
+
 // appendRecoverySuggestion extracts recovery suggestion data for logging.
 func appendRecoverySuggestion(logData []any, rs *RecoverySuggestion) []any {
 	logData = append(logData, "recovery_message", rs.Message)
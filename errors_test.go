@@ -1,11 +1,17 @@
 package ewrap
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"net/http"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 const (
@@ -131,6 +137,53 @@ func TestNewf(t *testing.T) {
 	}
 }
 
+func TestNewWithCause(t *testing.T) {
+	t.Parallel()
+
+	t.Run("present cause", func(t *testing.T) {
+		t.Parallel()
+
+		err := NewWithCause(msgWrapped, errOriginal, WithCode(codeNotFound))
+
+		if err.Error() != msgWrapped+": "+msgOriginal {
+			t.Errorf("unexpected message %q", err.Error())
+		}
+
+		if !errors.Is(err, errOriginal) || err.Cause() != errOriginal {
+			t.Error("expected the cause to be set")
+		}
+
+		if err.Code() != codeNotFound {
+			t.Errorf("expected options to apply, got code %q", err.Code())
+		}
+
+		if frames := err.GetStackFrames(); len(frames) == 0 || !strings.HasSuffix(frames[0].Function, "TestNewWithCause.func1") {
+			t.Errorf("expected the stack to start at the caller, got %+v", frames)
+		}
+	})
+
+	t.Run("nil cause", func(t *testing.T) {
+		t.Parallel()
+
+		if Wrap(nil, msgWrapped) != nil {
+			t.Fatal("Wrap(nil) must return nil")
+		}
+
+		err := NewWithCause(msgTestError, nil)
+		if err == nil {
+			t.Fatal("expected a cause-less error, got nil")
+		}
+
+		if err.Error() != msgTestError || err.Cause() != nil {
+			t.Errorf("unexpected error %q with cause %v", err.Error(), err.Cause())
+		}
+
+		if frames := err.GetStackFrames(); len(frames) == 0 || !strings.HasSuffix(frames[0].Function, "TestNewWithCause.func2") {
+			t.Errorf("expected the stack to start at the caller, got %+v", frames)
+		}
+	})
+}
+
 func TestWrap(t *testing.T) {
 	t.Parallel()
 
@@ -230,6 +283,31 @@ func TestError_Error(t *testing.T) {
 	})
 }
 
+//nolint:paralleltest // mutates the package-level message separator
+func TestSetMessageSeparator(t *testing.T) {
+	SetMessageSeparator(" -> ")
+	t.Cleanup(func() { SetMessageSeparator(defaultMessageSeparator) })
+
+	err := Wrap(Wrap(errRoot, msgWrapped), "outer")
+
+	const want = "outer -> " + msgWrapped + " -> " + msgRoot
+	if got := err.Error(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	if got := err.SafeError(); got != want {
+		t.Errorf("expected SafeError %q, got %q", want, got)
+	}
+
+	if !errors.Is(err, errRoot) {
+		t.Error("custom separator must not affect errors.Is")
+	}
+
+	if !errors.Is(err.Unwrap(), errRoot) {
+		t.Error("custom separator must not affect Unwrap")
+	}
+}
+
 func TestError_Cause(t *testing.T) {
 	t.Parallel()
 
@@ -252,6 +330,79 @@ func TestError_Cause(t *testing.T) {
 	})
 }
 
+func TestError_Causes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("three-level ewrap chain", func(t *testing.T) {
+		t.Parallel()
+
+		err := Wrap(Wrap(New(msgRoot), msgWrapped), "outer")
+
+		want := []string{"outer", msgWrapped, msgRoot}
+		if got := err.Causes(); !slices.Equal(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("chain ending in standard error", func(t *testing.T) {
+		t.Parallel()
+
+		err := Wrap(fmt.Errorf("stdlib: %w", errRoot), msgWrapped)
+
+		want := []string{msgWrapped, "stdlib: " + msgRoot}
+		if got := err.Causes(); !slices.Equal(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+}
+
+func TestError_Flatten(t *testing.T) {
+	t.Parallel()
+
+	root := New(msgRoot).WithMetadataMap(map[string]any{"shared": "root", "root_only": 1})
+	middle := Wrap(root, msgWrapped).WithMetadata("shared", "middle")
+	outer := Wrap(middle, "outer").WithMetadata("outer_only", true)
+
+	// Set after wrapping, so only Flatten's merge can surface it.
+	_ = root.WithMetadata("late_root", msgValue)
+
+	before := outer.Metadata()
+	flat := outer.Flatten()
+
+	if flat.Cause() != nil {
+		t.Errorf("expected no cause, got %v", flat.Cause())
+	}
+
+	const wantMsg = "outer: " + msgWrapped + ": " + msgRoot
+	if got := flat.Error(); got != wantMsg {
+		t.Errorf("message: got %q, want %q", got, wantMsg)
+	}
+
+	if got, _ := flat.GetString("shared"); got != "middle" {
+		t.Errorf("expected outer layer to win for shared key, got %q", got)
+	}
+
+	if got, ok := flat.GetInt("root_only"); !ok || got != 1 {
+		t.Errorf("expected root-only key to be merged, got %v (ok=%v)", got, ok)
+	}
+
+	if got, ok := flat.GetBool("outer_only"); !ok || !got {
+		t.Errorf("expected outer-only key to be kept, got %v (ok=%v)", got, ok)
+	}
+
+	if flat.Stack() != outer.Stack() {
+		t.Error("expected the outermost stack to be preserved")
+	}
+
+	if got, ok := flat.GetString("late_root"); !ok || got != msgValue {
+		t.Errorf("expected inner-only key to be merged, got %q (ok=%v)", got, ok)
+	}
+
+	if !maps.Equal(before, outer.Metadata()) {
+		t.Error("Flatten must not mutate the original chain")
+	}
+}
+
 func TestError_WithMetadata(t *testing.T) {
 	t.Parallel()
 
@@ -289,6 +440,32 @@ func TestError_WithContext(t *testing.T) {
 	}
 }
 
+func TestError_WithMetadataMap(t *testing.T) {
+	t.Parallel()
+
+	err := New(msgTest).WithMetadata(msgKey, "old")
+	result := err.WithMetadataMap(map[string]any{
+		msgKey:  msgValue,
+		"count": metadataIntValue,
+	})
+
+	if result != err {
+		t.Error("expected WithMetadataMap to return same error instance")
+	}
+
+	if val, ok := err.GetMetadata(msgKey); !ok || val != msgValue {
+		t.Errorf("expected %q to be overwritten with %q, got %v", msgKey, msgValue, val)
+	}
+
+	if val, ok := err.GetMetadata("count"); !ok || val != metadataIntValue {
+		t.Errorf("expected count %d, got %v", metadataIntValue, val)
+	}
+
+	if New(msgTest).WithMetadataMap(nil).metadata != nil {
+		t.Error("expected empty map to leave metadata unallocated")
+	}
+}
+
 func TestError_GetMetadata(t *testing.T) {
 	t.Parallel()
 
@@ -337,6 +514,134 @@ func TestError_GetMetadataValue(t *testing.T) {
 	}
 }
 
+func TestError_RemoveMetadata(t *testing.T) {
+	t.Parallel()
+
+	err := New(msgTest).WithMetadata(msgKey, msgValue).WithMetadata("keep", true)
+
+	if result := err.RemoveMetadata(msgKey); result != err {
+		t.Error("expected RemoveMetadata to return same error instance")
+	}
+
+	if _, ok := err.GetMetadata(msgKey); ok {
+		t.Error("expected key to be removed")
+	}
+
+	if _, ok := err.GetMetadata("keep"); !ok {
+		t.Error("expected unrelated key to survive removal")
+	}
+
+	_ = New(msgTest).RemoveMetadata("missing") // must not panic on nil map
+}
+
+func TestError_MetadataSnapshot(t *testing.T) {
+	t.Parallel()
+
+	err := New(
+		msgTest,
+		WithContext(context.Background(), ErrorTypeDatabase, SeverityError),
+		WithRetry(defaultMaxAttempts, time.Second),
+	).WithMetadata(msgKey, msgValue)
+
+	snapshot := err.Metadata()
+	if len(snapshot) != 1 || snapshot[msgKey] != msgValue {
+		t.Fatalf("expected only user metadata in snapshot, got %v", snapshot)
+	}
+
+	for _, reserved := range []string{"error_context", "retry_info"} {
+		if _, ok := snapshot[reserved]; ok {
+			t.Errorf("expected reserved key %q to be hidden", reserved)
+		}
+	}
+
+	snapshot[msgKey] = "mutated"
+	snapshot["added"] = true
+
+	if val, _ := err.GetMetadata(msgKey); val != msgValue {
+		t.Errorf("mutating snapshot leaked into error: got %v", val)
+	}
+
+	if _, ok := err.GetMetadata("added"); ok {
+		t.Error("adding to snapshot leaked into error")
+	}
+
+	if New(msgTest).Metadata() != nil {
+		t.Error("expected nil snapshot when no metadata is set")
+	}
+}
+
+func TestError_TypedMetadataAccessors(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	err := New(msgTest).WithMetadataMap(map[string]any{
+		"name":  msgValue,
+		"count": metadataIntValue,
+		"flag":  true,
+		"at":    now,
+	})
+
+	t.Run("present with correct type", func(t *testing.T) {
+		t.Parallel()
+
+		if v, ok := err.GetString("name"); !ok || v != msgValue {
+			t.Errorf("GetString: got %q (ok=%v)", v, ok)
+		}
+
+		if v, ok := err.GetInt("count"); !ok || v != metadataIntValue {
+			t.Errorf("GetInt: got %d (ok=%v)", v, ok)
+		}
+
+		if v, ok := err.GetBool("flag"); !ok || !v {
+			t.Errorf("GetBool: got %v (ok=%v)", v, ok)
+		}
+
+		if v, ok := err.GetTime("at"); !ok || !v.Equal(now) {
+			t.Errorf("GetTime: got %v (ok=%v)", v, ok)
+		}
+	})
+
+	t.Run("present with wrong type", func(t *testing.T) {
+		t.Parallel()
+
+		if _, ok := err.GetString("count"); ok {
+			t.Error("GetString: expected ok=false for int value")
+		}
+
+		if _, ok := err.GetInt("name"); ok {
+			t.Error("GetInt: expected ok=false for string value")
+		}
+
+		if _, ok := err.GetBool("name"); ok {
+			t.Error("GetBool: expected ok=false for string value")
+		}
+
+		if _, ok := err.GetTime("flag"); ok {
+			t.Error("GetTime: expected ok=false for bool value")
+		}
+	})
+
+	t.Run("absent", func(t *testing.T) {
+		t.Parallel()
+
+		if _, ok := err.GetString("missing"); ok {
+			t.Error("GetString: expected ok=false for missing key")
+		}
+
+		if _, ok := err.GetInt("missing"); ok {
+			t.Error("GetInt: expected ok=false for missing key")
+		}
+
+		if _, ok := err.GetBool("missing"); ok {
+			t.Error("GetBool: expected ok=false for missing key")
+		}
+
+		if _, ok := err.GetTime("missing"); ok {
+			t.Error("GetTime: expected ok=false for missing key")
+		}
+	})
+}
+
 func TestWithRecoverySuggestion(t *testing.T) {
 	t.Parallel()
 
@@ -450,6 +755,52 @@ func TestError_Log(t *testing.T) {
 	})
 }
 
+func TestError_LogFlattensContext(t *testing.T) {
+	t.Parallel()
+
+	mockLogger := NewMockLogger()
+	err := New(msgTest,
+		WithLogger(mockLogger),
+		WithContext(context.Background(), ErrorTypeDatabase, SeverityCritical),
+		WithRequestID("req-1"),
+		WithComponent("billing"),
+		WithOperation("charge"),
+		WithRetry(defaultMaxAttempts, time.Second),
+	)
+	err.Log()
+
+	logs := mockLogger.GetLogs()
+	entry := logs[len(logs)-1]
+	fields := make(map[any]any, len(entry.Args)/2)
+
+	for i := 0; i+1 < len(entry.Args); i += 2 {
+		fields[entry.Args[i]] = entry.Args[i+1]
+
+		switch entry.Args[i+1].(type) {
+		case *ErrorContext, ErrorContext, *RetryInfo, RetryInfo:
+			t.Errorf("key %v logged a raw %T", entry.Args[i], entry.Args[i+1])
+		}
+	}
+
+	want := map[string]string{
+		"type":       typeDatabaseStr,
+		"severity":   severityCriticalStr,
+		"request_id": "req-1",
+		"component":  "billing",
+		"operation":  "charge",
+	}
+
+	for key, val := range want {
+		if fields[key] != val {
+			t.Errorf("%s: got %v, want %q", key, fields[key], val)
+		}
+	}
+
+	if _, ok := fields["user"]; ok {
+		t.Error("an empty user should not be logged")
+	}
+}
+
 func TestCaptureStack(t *testing.T) {
 	t.Parallel()
 
@@ -576,3 +927,234 @@ func TestConcurrentAccess(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestConcurrentLogAndMetadata(t *testing.T) {
+	t.Parallel()
+
+	mockLogger := NewMockLogger()
+	err := New(msgTest, WithLogger(mockLogger))
+
+	var wg sync.WaitGroup
+
+	for i := range concurrentMetadataIters {
+		wg.Go(func() {
+			_ = err.WithMetadata(fmt.Sprintf("key%d", i), i)
+		})
+
+		wg.Go(err.Log)
+	}
+
+	wg.Wait()
+
+	if got := mockLogger.GetCallCount(severityErrorStr); got != concurrentMetadataIters {
+		t.Errorf("expected %d log records, got %d", concurrentMetadataIters, got)
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	t.Parallel()
+
+	root := New(msgRootCause)
+	err := Wrap(root, msgWrapped, WithHTTPStatus(http.StatusBadGateway)).WithMetadata(msgKey, msgValue)
+
+	annotated := err.Annotate("after 3 attempts")
+
+	if got, want := annotated.Error(), msgWrapped+"; after 3 attempts: "+msgRootCause; got != want {
+		t.Errorf("Error(): got %q, want %q", got, want)
+	}
+
+	if annotated.Cause() != root {
+		t.Error("Annotate must keep the existing cause, not add a layer")
+	}
+
+	if !slices.Equal(annotated.stack, err.stack) {
+		t.Error("Annotate must not capture a new stack")
+	}
+
+	if HTTPStatus(annotated) != http.StatusBadGateway {
+		t.Error("Annotate must keep attributes")
+	}
+
+	if v, ok := annotated.GetMetadata(msgKey); !ok || v != msgValue {
+		t.Errorf("metadata: got %v, %v", v, ok)
+	}
+
+	if err.Error() != msgWrapped+": "+msgRootCause {
+		t.Errorf("receiver modified: %q", err.Error())
+	}
+}
+
+func TestWithFreshMetadata(t *testing.T) {
+	t.Parallel()
+
+	inner := New(msgOriginal).WithMetadata("table", "users")
+
+	inherited := Wrap(inner, msgWrapped)
+	if v, ok := inherited.GetMetadata("table"); !ok || v != "users" {
+		t.Errorf("default wrap should inherit metadata, got %v, %v", v, ok)
+	}
+
+	fresh := Wrap(inner, msgWrapped, WithFreshMetadata()).WithMetadata("step", 2)
+	if _, ok := fresh.GetMetadata("table"); ok {
+		t.Error("WithFreshMetadata should drop the cause's metadata")
+	}
+
+	if v, ok := fresh.GetMetadata("step"); !ok || v != 2 {
+		t.Errorf("wrapper metadata lost, got %v, %v", v, ok)
+	}
+
+	if _, ok := inner.GetMetadata("step"); ok {
+		t.Error("wrapper metadata leaked into the cause")
+	}
+
+	if v, ok := inner.GetMetadata("table"); !ok || v != "users" {
+		t.Errorf("cause metadata changed, got %v, %v", v, ok)
+	}
+}
+
+func TestWithInitialMetadataCapacity(t *testing.T) {
+	t.Parallel()
+
+	inner := New(msgOriginal).WithMetadata(msgKey, msgValue)
+	wrapped := Wrap(inner, msgWrapped, WithInitialMetadataCapacity(smallStringLength)).WithMetadata("step", 2)
+
+	if v, ok := wrapped.GetMetadata(msgKey); !ok || v != msgValue {
+		t.Errorf("pre-sizing must keep inherited metadata, got %v, %v", v, ok)
+	}
+
+	if _, ok := inner.GetMetadata("step"); ok {
+		t.Error("pre-sized wrapper metadata leaked into the cause")
+	}
+
+	if got := New(msgTest, WithInitialMetadataCapacity(0)).metadata; got != nil {
+		t.Errorf("a non-positive capacity must not allocate, got %v", got)
+	}
+}
+
+// cyclicError unwraps to itself, forming a chain with no end. errors.As
+// never terminates on it, so tests attach it to an *Error by hand.
+type cyclicError struct{}
+
+func (c *cyclicError) Error() string { return "cycle" }
+
+func (c *cyclicError) Unwrap() error { return c }
+
+func TestUnwrapAll(t *testing.T) {
+	t.Parallel()
+
+	inner := Wrap(errOriginal, msgFirst)
+	std := fmt.Errorf("std layer: %w", inner)
+	outer := Wrap(std, msgSecond)
+
+	want := []error{outer, std, inner, errOriginal}
+	if got := outer.UnwrapAll(); !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if got := New(msgTestError).UnwrapAll(); len(got) != 1 {
+		t.Errorf("a leaf error should yield itself only, got %d entries", len(got))
+	}
+
+	cyclic := &Error{msg: msgWrapped, cause: &cyclicError{}}
+	if got := cyclic.UnwrapAll(); len(got) != maxUnwrapLayers {
+		t.Errorf("cyclic chain: got %d entries, want the %d cap", len(got), maxUnwrapLayers)
+	}
+}
+
+func TestWithMetadataFunc(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	err := New(msgTest).WithMetadataFunc("dump", func() any {
+		calls.Add(1)
+
+		return msgValue
+	})
+
+	if calls.Load() != 0 {
+		t.Fatal("fn must not run before the value is read")
+	}
+
+	wrapped := Wrap(err, msgWrapped)
+
+	if val, ok := err.GetMetadata("dump"); !ok || val != msgValue {
+		t.Errorf("GetMetadata: got %v, %v", val, ok)
+	}
+
+	if val, ok := wrapped.GetString("dump"); !ok || val != msgValue {
+		t.Errorf("inherited lazy value: got %q, %v", val, ok)
+	}
+
+	if got := err.ToErrorOutput().Metadata["dump"]; got != msgValue {
+		t.Errorf("serialized value: got %v", got)
+	}
+
+	if got := err.Metadata()["dump"]; got != msgValue {
+		t.Errorf("Metadata snapshot: got %v", got)
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("fn must run exactly once, ran %d times", calls.Load())
+	}
+}
+
+func TestSetCause(t *testing.T) {
+	t.Parallel()
+
+	err := Wrap(errOriginal, msgWrapped).WithMetadata(msgKey, msgValue)
+	_ = err.Error() // populate the cache before swapping
+
+	sanitized := New(msgSentinel)
+	if got := err.SetCause(sanitized); got != err {
+		t.Error("expected SetCause to return the same error instance")
+	}
+
+	if got, want := err.Error(), msgWrapped+": "+msgSentinel; got != want {
+		t.Errorf("Error(): got %q, want %q", got, want)
+	}
+
+	if err.Unwrap() != sanitized || errors.Is(err, errOriginal) {
+		t.Error("Unwrap must return the new cause only")
+	}
+
+	if val, _ := err.GetMetadata(msgKey); val != msgValue {
+		t.Error("SetCause must keep metadata")
+	}
+
+	if err.SetCause(nil).Error() != msgWrapped {
+		t.Errorf("a nil cause should leave only the message, got %q", err.Error())
+	}
+}
+
+func TestSetCauseConcurrentWithError(t *testing.T) {
+	t.Parallel()
+
+	err := Wrap(errOriginal, msgWrapped)
+	sanitized := New(msgSentinel)
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for range 1000 {
+			_ = err.Error()
+			_ = err.Unwrap()
+		}
+	}()
+
+	for i := range 1000 {
+		if i%2 == 0 {
+			err.SetCause(sanitized)
+		} else {
+			err.SetCause(errOriginal)
+		}
+	}
+
+	<-done
+
+	if got, want := err.Error(), msgWrapped+": "+errOriginal.Error(); got != want {
+		t.Errorf("Error(): got %q, want %q", got, want)
+	}
+}
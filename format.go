@@ -4,15 +4,28 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"slices"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
-	"github.com/goccy/go-json"
 	"gopkg.in/yaml.v3"
 )
 
+// SchemaVersion identifies the shape of ErrorOutput. It is bumped whenever a
+// change could break a downstream parser, and ToJSON, ToYAML and
+// ToErrorOutput stamp it into the top-level schema_version field.
+const SchemaVersion = "1"
+
+// truncatedMarker ends metadata values cut short by SetMaxMetadataValueSize.
+const truncatedMarker = "...(truncated)"
+
 // ErrorOutput represents a formatted error output structure that can be
 // serialized to various formats like JSON and YAML.
 type ErrorOutput struct {
+	// SchemaVersion is the SchemaVersion constant on the top-level output,
+	// and empty on causes or when WithSchemaVersion(false) is set.
+	SchemaVersion string `json:"schema_version,omitempty" yaml:"schema_version,omitempty"`
 	// Message contains the main error message
 	Message string `json:"message" yaml:"message"`
 	// Timestamp indicates when the error occurred
@@ -21,8 +34,15 @@ type ErrorOutput struct {
 	Type string `json:"type" yaml:"type"`
 	// Severity indicates the error's impact level
 	Severity string `json:"severity" yaml:"severity"`
+	// Code is the machine-readable code attached via WithCode, if any
+	Code string `json:"code,omitempty" yaml:"code,omitempty"`
+	// Tags lists the labels attached via WithTags
+	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"`
 	// Stack contains the error stack trace
 	Stack string `json:"stack" yaml:"stack"`
+	// Fingerprint is the error's Hash, for grouping occurrences; empty on
+	// non-ewrap causes or when WithFingerprint(false) is set.
+	Fingerprint string `json:"fingerprint,omitempty" yaml:"fingerprint,omitempty"`
 	// Cause contains the underlying error if any
 	Cause *ErrorOutput `json:"cause,omitempty" yaml:"cause,omitempty"`
 	// Context contains additional error context
@@ -31,56 +51,255 @@ type ErrorOutput struct {
 	Metadata map[string]any `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 	// Recovery provides guidance on resolving the error
 	Recovery *RecoverySuggestion `json:"recovery,omitempty" yaml:"recovery,omitempty"`
+	// Depth is the number of chain levels from this node down, counting
+	// itself: 1 for a leaf, 3 for an error wrapped twice.
+	Depth int `json:"depth" yaml:"depth"`
+
+	// stack is the captured stack Stack is rendered from, kept so that
+	// WithStackTrace(true) can restore a stack an earlier option removed.
+	stack string
+	// timestamp is the source time Timestamp is rendered from. Keeping it
+	// alongside the string lets timestamp options re-render from the
+	// original value regardless of how many have already been applied.
+	timestamp time.Time
+	// slackStackFrames caps the frames ToSlackMessage renders; zero means
+	// the default. Set via WithSlackStackFrames.
+	slackStackFrames int
+	// maxStackFrames caps the frames kept in Stack and in group
+	// serialization; zero keeps them all. Set via WithMaxStackFrames.
+	maxStackFrames int
+	// sortMetadata makes ToJSON and ToYAML emit metadata keys in
+	// alphabetical order. Set via WithSortedMetadata.
+	sortMetadata bool
+	// omitSchemaVersion leaves SchemaVersion empty. Set via
+	// WithSchemaVersion(false).
+	omitSchemaVersion bool
+	// omitFingerprint leaves Fingerprint empty, sparing the hash. Set via
+	// WithFingerprint(false).
+	omitFingerprint bool
+	// severity is the level Severity is rendered from, consulted by
+	// WithStackTraceFrom.
+	severity Severity
+	// minStackSeverity is the lowest severity whose stack is kept; the zero
+	// value, SeverityInfo, keeps every stack. Set via WithStackTraceFrom.
+	minStackSeverity Severity
+	// compactStack renders group stack frames as single-line strings in
+	// YAML. Set via WithCompactStack.
+	compactStack bool
+	// dropMissingPlaceholders removes recovery placeholders that have no
+	// matching metadata key. Set via WithMissingPlaceholdersEmpty.
+	dropMissingPlaceholders bool
 }
 
 // FormatOption defines formatting options for error output.
 type FormatOption func(*ErrorOutput)
 
 // WithTimestampFormat allows customizing the timestamp format in the output.
+// The timestamp is rendered from the error's creation time, so applying
+// several timestamp options is order-independent: the last one wins.
 func WithTimestampFormat(format string) FormatOption {
 	return func(eo *ErrorOutput) {
 		if format == "" {
 			return
 		}
 
-		t, err := time.Parse(time.RFC3339, eo.Timestamp)
-		if err == nil {
-			eo.Timestamp = t.Format(format)
+		if eo.timestamp.IsZero() {
+			// Outputs built by hand carry only the string form; fall back to
+			// parsing it as RFC3339 and remember the result for later options.
+			t, err := time.Parse(time.RFC3339, eo.Timestamp)
+			if err != nil {
+				return
+			}
+
+			eo.timestamp = t
 		}
+
+		eo.Timestamp = eo.timestamp.Format(format)
 	}
 }
 
 // WithStackTrace controls whether to include the stack trace in the output.
+// WithStackTrace(true) restores a stack removed by an earlier option, such
+// as a SetDefaultFormatOptions default, still honoring WithStackTraceFrom
+// and WithMaxStackFrames.
 func WithStackTrace(include bool) FormatOption {
 	return func(eo *ErrorOutput) {
 		if !include {
 			eo.Stack = ""
+
+			return
+		}
+
+		if eo.stack == "" {
+			return
+		}
+
+		eo.Stack = eo.stack
+
+		if eo.severity < eo.minStackSeverity {
+			eo.Stack = ""
+		}
+
+		if eo.maxStackFrames > 0 && eo.Stack != "" {
+			eo.Stack = truncateStackFrames(eo.Stack, eo.maxStackFrames) + "\n"
+		}
+	}
+}
+
+// WithStackTraceFrom keeps the stack trace only on errors whose severity is
+// at least minSeverity, blanking it on the others as WithStackTrace(false)
+// does, so routine errors stay lean. Errors without an ErrorContext count as
+// SeverityError. It narrows, never widens: WithStackTrace(false) still drops
+// every stack.
+func WithStackTraceFrom(minSeverity Severity) FormatOption {
+	return func(eo *ErrorOutput) {
+		eo.minStackSeverity = minSeverity
+
+		if eo.severity < minSeverity {
+			eo.Stack = ""
+		}
+	}
+}
+
+// WithMaxStackFrames keeps only the top n stack frames in serialized
+// output, followed by a "... N more frames" line in Stack (or an
+// omitted_frames count in group output) when frames were dropped. n <= 0
+// keeps every frame. WithStackTrace(false) wins regardless of order.
+func WithMaxStackFrames(n int) FormatOption {
+	return func(eo *ErrorOutput) {
+		if n <= 0 {
+			return
+		}
+
+		eo.maxStackFrames = n
+
+		if eo.Stack != "" {
+			eo.Stack = truncateStackFrames(eo.Stack, n) + "\n"
 		}
 	}
 }
 
+// WithCompactStack makes group YAML output render each stack frame as a
+// single "file:line function" string instead of a mapping of its fields,
+// roughly halving the size of YAML dumps. JSON keeps structured frames, and
+// single-error output, whose stack is already one string, is unchanged.
+func WithCompactStack() FormatOption {
+	return func(eo *ErrorOutput) {
+		eo.compactStack = true
+	}
+}
+
+// WithMissingPlaceholdersEmpty makes {key} placeholders in the recovery
+// suggestion that have no matching metadata key render as empty text. By
+// default they are kept verbatim, so a missing value stays visible.
+func WithMissingPlaceholdersEmpty() FormatOption {
+	return func(eo *ErrorOutput) {
+		eo.dropMissingPlaceholders = true
+	}
+}
+
+// WithSortedMetadata makes ToJSON and ToYAML emit metadata keys, including
+// those of nested string-keyed maps, in alphabetical order. The bundled
+// encoders already sort map keys, but a custom JSONMarshaler may not; this
+// option guarantees stable output for golden-file tests regardless.
+func WithSortedMetadata() FormatOption {
+	return func(eo *ErrorOutput) {
+		eo.sortMetadata = true
+	}
+}
+
+// WithSchemaVersion controls whether the top-level output carries the
+// schema_version field. It is included by default; pass false for consumers
+// that reject unknown fields.
+func WithSchemaVersion(include bool) FormatOption {
+	return func(eo *ErrorOutput) {
+		eo.omitSchemaVersion = !include
+	}
+}
+
+// WithFingerprint controls whether each ewrap layer of the output carries
+// its Hash in the fingerprint field, so log aggregators can group repeat
+// occurrences without computing it themselves. It is included by default.
+func WithFingerprint(include bool) FormatOption {
+	return func(eo *ErrorOutput) {
+		eo.omitFingerprint = !include
+	}
+}
+
+//nolint:gochecknoglobals // package-wide formatting policy, swapped atomically
+var defaultFormatOptions atomic.Pointer[[]FormatOption]
+
+// SetDefaultFormatOptions installs format options applied to every
+// serialization (ToJSON, ToYAML, ToErrorOutput, ToLogfmt, ToSlackMessage,
+// NDJSON streams and ErrorGroup output) ahead of the per-call options, so a
+// per-call option overrides the default it conflicts with. Calling it with
+// no options removes the defaults. Configure it once at startup.
+func SetDefaultFormatOptions(opts ...FormatOption) {
+	if len(opts) == 0 {
+		defaultFormatOptions.Store(nil)
+
+		return
+	}
+
+	defaults := slices.Clone(opts)
+	defaultFormatOptions.Store(&defaults)
+}
+
+// withDefaultFormatOptions prepends the SetDefaultFormatOptions defaults to
+// opts.
+func withDefaultFormatOptions(opts []FormatOption) []FormatOption {
+	defaults := defaultFormatOptions.Load()
+	if defaults == nil {
+		return opts
+	}
+
+	return slices.Concat(*defaults, opts)
+}
+
+// ToErrorOutput returns the structure ToJSON and ToYAML serialize, with opts
+// applied, so encoders outside this package can produce the same shape.
+func (e *Error) ToErrorOutput(opts ...FormatOption) *ErrorOutput {
+	output := e.toErrorOutput(withDefaultFormatOptions(opts)...)
+	if !output.omitSchemaVersion {
+		output.SchemaVersion = SchemaVersion
+	}
+
+	return output
+}
+
 // toErrorOutput converts an Error to ErrorOutput format.
 func (e *Error) toErrorOutput(opts ...FormatOption) *ErrorOutput {
 	e.mu.RLock()
+	metadataCopy := serializedMetadata(e.metadata)
+	e.mu.RUnlock()
 
-	metadataCopy := make(map[string]any, len(e.metadata))
-	maps.Copy(metadataCopy, e.metadata)
+	created := e.createdAt
+	if created.IsZero() {
+		created = currentTime()
+	}
 
-	e.mu.RUnlock()
+	stack := e.Stack()
 
 	output := &ErrorOutput{
 		Message:   e.msg,
-		Timestamp: time.Now().Format(time.RFC3339),
+		Timestamp: created.Format(time.RFC3339),
 		Type:      typeUnknownStr,
 		Severity:  severityErrorStr,
-		Stack:     e.Stack(),
+		Code:      e.code.String(),
+		Stack:     stack,
+		Tags:      e.Tags(),
 		Metadata:  metadataCopy,
-		Recovery:  e.recovery,
+		Recovery:  e.effectiveRecovery(),
+		Depth:     1,
+		stack:     stack,
+		timestamp: created,
+		severity:  SeverityError,
 	}
 
 	if ctx := e.errorContext; ctx != nil {
 		output.Type = ctx.Type.String()
 		output.Severity = ctx.Severity.String()
+		output.severity = ctx.Severity
 		output.Context = map[string]any{
 			"request_id":  ctx.RequestID,
 			"user":        ctx.User,
@@ -89,52 +308,132 @@ func (e *Error) toErrorOutput(opts ...FormatOption) *ErrorOutput {
 			"file":        ctx.File,
 			"line":        ctx.Line,
 			"environment": ctx.Environment,
+			"version":     ctx.Version,
+			"timed_out":   ctx.TimedOut,
+		}
+
+		if !ctx.Deadline.IsZero() {
+			output.Context["deadline"] = ctx.Deadline.Format(time.RFC3339Nano)
+		}
+
+		if len(ctx.Data) > 0 {
+			output.Context["data"] = maps.Clone(ctx.Data)
 		}
 	}
 
 	if e.cause != nil {
-		var wrappedErr *Error
-		if errors.As(e.cause, &wrappedErr) {
+		if wrappedErr, ok := e.cause.(*Error); ok {
 			output.Cause = wrappedErr.toErrorOutput(opts...)
 		} else {
-			output.Cause = standardErrorOutput(e.cause)
+			output.Cause = standardErrorOutput(e.cause, opts...)
 		}
+
+		output.Depth = output.Cause.Depth + 1
 	}
 
 	for _, opt := range opts {
 		opt(output)
 	}
 
+	if !output.omitFingerprint {
+		output.Fingerprint = e.Hash()
+	}
+
+	output.Recovery = output.Recovery.interpolate(output.Metadata, !output.dropMissingPlaceholders)
+
 	return output
 }
 
 // standardErrorOutput renders a non-ewrap error and walks any further chain
 // via errors.Unwrap so JSON/YAML output preserves the full cause history.
-func standardErrorOutput(err error) *ErrorOutput {
+// opts are passed on to any *Error found further down the chain.
+func standardErrorOutput(err error, opts ...FormatOption) *ErrorOutput {
 	out := &ErrorOutput{
 		Message:  err.Error(),
 		Type:     typeUnknownStr,
 		Severity: severityErrorStr,
+		Depth:    1,
 	}
 
 	cause := errors.Unwrap(err)
 	if cause != nil {
-		var wrappedErr *Error
-		if errors.As(cause, &wrappedErr) {
-			out.Cause = wrappedErr.toErrorOutput()
+		if wrappedErr, ok := cause.(*Error); ok {
+			out.Cause = wrappedErr.toErrorOutput(opts...)
 		} else {
-			out.Cause = standardErrorOutput(cause)
+			out.Cause = standardErrorOutput(cause, opts...)
+		}
+
+		out.Depth = out.Cause.Depth + 1
+	}
+
+	return out
+}
+
+//nolint:gochecknoglobals // package-wide serialization policy, swapped atomically
+var maxMetadataValueSize atomic.Int64
+
+// SetMaxMetadataValueSize caps how many bytes of a string or []byte metadata
+// value are serialized; longer values are cut to n bytes and suffixed with
+// "...(truncated)", and a cut []byte is serialized as a string so the marker
+// stays readable. Only serialized output is affected: the value stored on the
+// error, and what GetMetadata and Log see, stay intact. Other value types are
+// left alone. n <= 0 (the default) disables the cap.
+func SetMaxMetadataValueSize(n int) {
+	maxMetadataValueSize.Store(int64(max(n, 0)))
+}
+
+// serializedMetadata copies metadata for serialization, evaluating lazy
+// values and truncating those over the SetMaxMetadataValueSize cap. The
+// caller must hold the owning error's lock.
+func serializedMetadata(metadata map[string]any) map[string]any {
+	out := make(map[string]any, len(metadata))
+	limit := int(maxMetadataValueSize.Load())
+
+	for key, val := range metadata {
+		val = resolveMetadata(val)
+		if limit > 0 {
+			val = truncateMetadataValue(val, limit)
 		}
+
+		out[key] = val
 	}
 
 	return out
 }
 
+// truncateMetadataValue shortens string and []byte values longer than limit
+// bytes. Strings are cut on a rune boundary so the result stays valid UTF-8.
+// A truncated []byte becomes a string, so JSON shows the marker instead of
+// base64-encoding it along with the bytes.
+func truncateMetadataValue(val any, limit int) any {
+	switch v := val.(type) {
+	case string:
+		if len(v) <= limit {
+			return v
+		}
+
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(v[cut]) {
+			cut--
+		}
+
+		return v[:cut] + truncatedMarker
+	case []byte:
+		if len(v) <= limit {
+			return v
+		}
+
+		return string(v[:limit]) + truncatedMarker
+	default:
+		return val
+	}
+}
+
 // ToJSON converts the error to a JSON string.
 func (e *Error) ToJSON(opts ...FormatOption) (string, error) {
-	output := e.toErrorOutput(opts...)
+	output := e.ToErrorOutput(opts...)
 
-	data, err := json.MarshalIndent(output, "", "  ")
+	data, err := jsonMarshaler().MarshalIndent(output.marshalTarget(), "", "  ")
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal error to JSON: %w", err)
 	}
@@ -144,9 +443,9 @@ func (e *Error) ToJSON(opts ...FormatOption) (string, error) {
 
 // ToYAML converts the error to a YAML string.
 func (e *Error) ToYAML(opts ...FormatOption) (string, error) {
-	output := e.toErrorOutput(opts...)
+	output := e.ToErrorOutput(opts...)
 
-	data, err := yaml.Marshal(output)
+	data, err := yaml.Marshal(output.marshalTarget())
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal error to YAML: %w", err)
 	}
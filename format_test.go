@@ -1,6 +1,7 @@
 package ewrap
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -69,6 +70,28 @@ func TestWithStackTrace(t *testing.T) {
 	}
 }
 
+func TestWithStackTraceThroughStandardLayer(t *testing.T) {
+	t.Parallel()
+
+	inner := New(msgRoot)
+	outer := Wrap(fmt.Errorf("std layer: %w", inner), msgWrapped)
+
+	output := outer.ToErrorOutput(WithStackTrace(false), WithFingerprint(false))
+
+	depth := 0
+	for node := output; node != nil; node = node.Cause {
+		if node.Stack != "" || node.Fingerprint != "" {
+			t.Errorf("level %d (%q) kept its stack or fingerprint", depth, node.Message)
+		}
+
+		depth++
+	}
+
+	if depth != 3 {
+		t.Fatalf("expected three levels, got %d", depth)
+	}
+}
+
 func TestToErrorOutput(t *testing.T) {
 	t.Parallel()
 
@@ -316,3 +339,463 @@ func TestToErrorOutputWithRecoverySuggestion(t *testing.T) {
 		t.Errorf("expected documentation %q, got %q", rs.Documentation, output.Recovery.Documentation)
 	}
 }
+
+func TestWithTimestampFormatChained(t *testing.T) {
+	t.Parallel()
+
+	err := New(msgTestError)
+
+	output := err.toErrorOutput(
+		WithTimestampFormat(dateOnlyLayout),
+		WithTimestampFormat(time.Kitchen),
+	)
+
+	want := err.createdAt.Format(time.Kitchen)
+	if output.Timestamp != want {
+		t.Errorf("expected last timestamp format to win: got %q, want %q", output.Timestamp, want)
+	}
+}
+
+func TestErrorOutputCode(t *testing.T) {
+	t.Parallel()
+
+	err := Wrap(New(msgRoot), msgWrapped, WithCode(codeNotFound))
+
+	for _, opts := range [][]FormatOption{nil, {WithSortedMetadata()}} {
+		jsonStr, jsonErr := err.ToJSON(opts...)
+		if jsonErr != nil {
+			t.Fatalf(unexpectedErrFn, jsonErr)
+		}
+
+		var output ErrorOutput
+		if unmarshalErr := json.Unmarshal([]byte(jsonStr), &output); unmarshalErr != nil {
+			t.Fatalf("failed to decode JSON: %v", unmarshalErr)
+		}
+
+		if output.Code != codeNotFound.String() {
+			t.Errorf("code: got %q, want %q", output.Code, codeNotFound)
+		}
+
+		if output.Cause == nil || output.Cause.Code != "" {
+			t.Errorf("a cause without a code must not report one: %+v", output.Cause)
+		}
+	}
+
+	if jsonStr, _ := New(msgTest).ToJSON(); strings.Contains(jsonStr, `"code"`) {
+		t.Errorf("an error without a code must omit the field: %s", jsonStr)
+	}
+}
+
+func TestWithSortedMetadata(t *testing.T) {
+	t.Parallel()
+
+	err := New("sorted").WithMetadataMap(map[string]any{
+		"zulu":    1,
+		"alpha":   "a",
+		"mike":    true,
+		"charlie": map[string]any{"y": 1, "b": 2},
+		"echo":    []int{3, 2, 1},
+	})
+
+	encoders := map[string]func(...FormatOption) (string, error){
+		"json": err.ToJSON,
+		"yaml": err.ToYAML,
+	}
+
+	for name, encode := range encoders {
+		first, encErr := encode(WithSortedMetadata(), WithStackTrace(false))
+		if encErr != nil {
+			t.Fatalf("%s: %v", name, encErr)
+		}
+
+		for range 20 {
+			again, encErr := encode(WithSortedMetadata(), WithStackTrace(false))
+			if encErr != nil {
+				t.Fatalf("%s: %v", name, encErr)
+			}
+
+			if again != first {
+				t.Fatalf("%s output not stable:\n%s\nvs\n%s", name, first, again)
+			}
+		}
+
+		last := -1
+
+		for _, key := range []string{"alpha", "charlie", "echo", "mike", "zulu"} {
+			idx := strings.Index(first, key)
+			if idx <= last {
+				t.Fatalf("%s: key %q out of order in:\n%s", name, key, first)
+			}
+
+			last = idx
+		}
+
+		if strings.Index(first, `"b": 2`) > strings.Index(first, `"y": 1`) && name == "json" {
+			t.Fatalf("json: nested keys not sorted:\n%s", first)
+		}
+	}
+
+	var decoded map[string]any
+
+	out, _ := err.ToJSON(WithSortedMetadata())
+
+	unmarshalErr := json.Unmarshal([]byte(out), &decoded)
+	if unmarshalErr != nil {
+		t.Fatalf("sorted JSON does not round-trip: %v", unmarshalErr)
+	}
+
+	meta, ok := decoded["metadata"].(map[string]any)
+	if !ok || len(meta) != 5 {
+		t.Fatalf("metadata: got %v", decoded["metadata"])
+	}
+}
+
+func TestToErrorOutputDepth(t *testing.T) {
+	t.Parallel()
+
+	leaf := New(msgRoot)
+	if got := leaf.toErrorOutput().Depth; got != 1 {
+		t.Errorf("leaf depth: got %d, want 1", got)
+	}
+
+	three := Wrap(Wrap(leaf, "middle"), "outer")
+
+	output := three.toErrorOutput()
+	if output.Depth != 3 {
+		t.Errorf("top depth: got %d, want 3", output.Depth)
+	}
+
+	if output.Cause.Depth != 2 || output.Cause.Cause.Depth != 1 {
+		t.Errorf("cause depths: got %d, %d", output.Cause.Depth, output.Cause.Cause.Depth)
+	}
+
+	// Standard library layers count too.
+	mixed := Wrap(fmt.Errorf("std: %w", errOriginal), msgWrapped)
+	if got := mixed.toErrorOutput().Depth; got != 3 {
+		t.Errorf("mixed depth: got %d, want 3", got)
+	}
+
+	jsonStr, err := three.ToJSON(WithStackTrace(false))
+	if err != nil {
+		t.Fatalf(unexpectedErrFn, err)
+	}
+
+	if !strings.Contains(jsonStr, `"depth": 3`) {
+		t.Errorf("JSON missing depth: %s", jsonStr)
+	}
+}
+
+// deepStackError builds an error whose stack has at least depth frames of
+// recursion above the test function.
+func deepStackError(depth int) *Error {
+	if depth == 0 {
+		return New(msgTestError)
+	}
+
+	return deepStackError(depth - 1)
+}
+
+func TestWithMaxStackFrames(t *testing.T) {
+	t.Parallel()
+
+	const limit = 2
+
+	err := deepStackError(5)
+
+	total := len(err.GetStackFrames())
+	if total <= limit {
+		t.Fatalf("test needs more than %d frames, got %d", limit, total)
+	}
+
+	output := err.toErrorOutput(WithMaxStackFrames(limit))
+	lines := strings.Split(strings.TrimRight(output.Stack, "\n"), "\n")
+
+	if len(lines) != limit+1 {
+		t.Fatalf("Stack lines: got %d, want %d:\n%s", len(lines), limit+1, output.Stack)
+	}
+
+	if want := fmt.Sprintf("... %d more frames", total-limit); lines[limit] != want {
+		t.Errorf("omission line: got %q, want %q", lines[limit], want)
+	}
+
+	for _, opts := range [][]FormatOption{
+		{WithStackTrace(false), WithMaxStackFrames(limit)},
+		{WithMaxStackFrames(limit), WithStackTrace(false)},
+	} {
+		if got := err.toErrorOutput(opts...).Stack; got != "" {
+			t.Errorf("WithStackTrace(false) must win, got %q", got)
+		}
+	}
+
+	group := ErrorGroupFromErrors(err).toSerialization(resolveGroupFormat([]FormatOption{WithMaxStackFrames(limit)}))
+
+	member := group.Errors[0]
+	if len(member.StackTrace) != limit || member.OmittedFrames != total-limit {
+		t.Errorf("group frames: got %d (omitted %d), want %d (omitted %d)",
+			len(member.StackTrace), member.OmittedFrames, limit, total-limit)
+	}
+
+	untouched := ErrorGroupFromErrors(err).toSerialization(resolveGroupFormat(nil)).Errors[0]
+	if len(untouched.StackTrace) != total || untouched.OmittedFrames != 0 {
+		t.Errorf("without the option all %d frames must be kept, got %d", total, len(untouched.StackTrace))
+	}
+}
+
+func TestSchemaVersion(t *testing.T) {
+	t.Parallel()
+
+	err := Wrap(New(msgOriginal), msgWrapped)
+
+	jsonStr, jsonErr := err.ToJSON()
+	if jsonErr != nil {
+		t.Fatalf(unexpectedErrFn, jsonErr)
+	}
+
+	var output ErrorOutput
+	if unmarshalErr := json.Unmarshal([]byte(jsonStr), &output); unmarshalErr != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", unmarshalErr)
+	}
+
+	if output.SchemaVersion != SchemaVersion {
+		t.Errorf("schema_version: got %q, want %q", output.SchemaVersion, SchemaVersion)
+	}
+
+	if output.Cause == nil || output.Cause.SchemaVersion != "" {
+		t.Error("schema_version belongs on the top-level output only")
+	}
+
+	yamlStr, yamlErr := err.ToYAML()
+	if yamlErr != nil {
+		t.Fatalf(unexpectedErrFn, yamlErr)
+	}
+
+	if !strings.Contains(yamlStr, "schema_version: \""+SchemaVersion+"\"") {
+		t.Errorf("expected schema_version in YAML output:\n%s", yamlStr)
+	}
+
+	jsonStr, jsonErr = err.ToJSON(WithSchemaVersion(false))
+	if jsonErr != nil {
+		t.Fatalf(unexpectedErrFn, jsonErr)
+	}
+
+	if strings.Contains(jsonStr, "schema_version") {
+		t.Errorf("WithSchemaVersion(false) should omit the field:\n%s", jsonStr)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+
+	fingerprintOf := func(err *Error, opts ...FormatOption) *ErrorOutput {
+		t.Helper()
+
+		jsonStr, jsonErr := err.ToJSON(opts...)
+		if jsonErr != nil {
+			t.Fatalf(unexpectedErrFn, jsonErr)
+		}
+
+		var output ErrorOutput
+		if unmarshalErr := json.Unmarshal([]byte(jsonStr), &output); unmarshalErr != nil {
+			t.Fatalf("Failed to unmarshal JSON: %v", unmarshalErr)
+		}
+
+		return &output
+	}
+
+	errs := make([]*Error, 2)
+	for i := range errs {
+		errs[i] = Wrap(New(msgOriginal), msgWrapped).WithMetadata(msgKey, i)
+	}
+
+	first, second := fingerprintOf(errs[0]), fingerprintOf(errs[1])
+	if first.Fingerprint == "" || first.Fingerprint != second.Fingerprint {
+		t.Errorf("identical errors must share a fingerprint, got %q and %q", first.Fingerprint, second.Fingerprint)
+	}
+
+	if first.Fingerprint != errs[0].Hash() {
+		t.Errorf("fingerprint should equal Hash(), got %q", first.Fingerprint)
+	}
+
+	if first.Cause == nil || first.Cause.Fingerprint == "" || first.Cause.Fingerprint == first.Fingerprint {
+		t.Error("each ewrap layer should carry its own fingerprint")
+	}
+
+	if other := fingerprintOf(Wrap(New(msgOriginal), msgTest)); other.Fingerprint == first.Fingerprint {
+		t.Error("different messages must not share a fingerprint")
+	}
+
+	if omitted := fingerprintOf(errs[0], WithFingerprint(false)); omitted.Fingerprint != "" || omitted.Cause.Fingerprint != "" {
+		t.Error("WithFingerprint(false) should omit the field on every layer")
+	}
+}
+
+func TestWithStackTraceFrom(t *testing.T) {
+	t.Parallel()
+
+	onlyCritical := WithStackTraceFrom(SeverityCritical)
+
+	info := New(msgTestError, WithSeverity(SeverityInfo))
+	if out := info.ToErrorOutput(onlyCritical); out.Stack != "" {
+		t.Errorf("expected no stack on an info error, got %q", out.Stack)
+	}
+
+	critical := Wrap(info, msgWrapped, WithEscalateSeverity(SeverityCritical))
+
+	out := critical.ToErrorOutput(onlyCritical)
+	if out.Stack == "" {
+		t.Error("expected a stack on a critical error")
+	}
+
+	if out.Cause == nil || out.Cause.Stack != "" {
+		t.Error("the info-level cause should still lose its stack")
+	}
+
+	if out := critical.ToErrorOutput(onlyCritical, WithStackTrace(false)); out.Stack != "" {
+		t.Error("WithStackTrace(false) must still drop the stack")
+	}
+
+	if out := critical.ToErrorOutput(WithStackTrace(false), onlyCritical); out.Stack != "" {
+		t.Error("WithStackTraceFrom must not restore a dropped stack")
+	}
+
+	if out := New(msgTestError).ToErrorOutput(WithStackTraceFrom(SeverityError)); out.Stack == "" {
+		t.Error("errors without a context count as SeverityError")
+	}
+
+	group := ErrorGroupFromErrors(info, critical).ToSerialization(onlyCritical)
+	if len(group.Errors[0].StackTrace) != 0 || len(group.Errors[1].StackTrace) == 0 {
+		t.Error("group serialization should keep stacks on critical members only")
+	}
+}
+
+//nolint:paralleltest // mutates the package-level metadata value cap
+func TestSetMaxMetadataValueSize(t *testing.T) {
+	SetMaxMetadataValueSize(8)
+	t.Cleanup(func() { SetMaxMetadataValueSize(0) })
+
+	huge := strings.Repeat("x", smallStringLength)
+	err := New(msgTestError).
+		WithMetadata("text", huge).
+		WithMetadata("blob", []byte(huge)).
+		WithMetadata("short", "ok").
+		WithMetadata("count", smallStringLength).
+		WithMetadata("utf8", "ééééé")
+
+	output := err.ToErrorOutput()
+
+	want := map[string]any{
+		"text":  "xxxxxxxx" + truncatedMarker,
+		"blob":  "xxxxxxxx" + truncatedMarker,
+		"short": "ok",
+		"count": smallStringLength,
+		"utf8":  "éééé" + truncatedMarker,
+	}
+
+	for key, val := range want {
+		if output.Metadata[key] != val {
+			t.Errorf("%s: got %v, want %v", key, output.Metadata[key], val)
+		}
+	}
+
+	jsonStr, jsonErr := err.ToJSON()
+	if jsonErr != nil {
+		t.Fatalf(unexpectedErrFn, jsonErr)
+	}
+
+	if !strings.Contains(jsonStr, `"blob": "xxxxxxxx`+truncatedMarker+`"`) {
+		t.Errorf("expected the truncated []byte to show the marker in JSON, got %s", jsonStr)
+	}
+
+	if val, _ := err.GetMetadata("text"); val != huge {
+		t.Error("the in-memory value must stay intact")
+	}
+
+	group := NewErrorGroup()
+	group.Add(err)
+
+	if got := group.ToSerialization().Errors[0].Metadata["text"]; got != want["text"] {
+		t.Errorf("group serialization: got %v, want %v", got, want["text"])
+	}
+}
+
+//nolint:paralleltest // mutates the package-level default format options
+func TestSetDefaultFormatOptions(t *testing.T) {
+	SetDefaultFormatOptions(WithStackTrace(false), WithTimestampFormat(dateOnlyLayout), WithSchemaVersion(false))
+	t.Cleanup(func() { SetDefaultFormatOptions() })
+
+	err := New(msgTest)
+
+	output := err.ToErrorOutput()
+	if output.Stack != "" {
+		t.Error("expected the default WithStackTrace(false) to drop the stack")
+	}
+
+	if want := err.createdAt.Format(dateOnlyLayout); output.Timestamp != want {
+		t.Errorf("Timestamp: got %q, want %q", output.Timestamp, want)
+	}
+
+	// Per-call options run after the defaults and win.
+	output = err.ToErrorOutput(WithTimestampFormat(time.RFC3339), WithSchemaVersion(true))
+	if want := err.createdAt.Format(time.RFC3339); output.Timestamp != want {
+		t.Errorf("per-call Timestamp: got %q, want %q", output.Timestamp, want)
+	}
+
+	if output.SchemaVersion != SchemaVersion {
+		t.Errorf("per-call schema version: got %q, want %q", output.SchemaVersion, SchemaVersion)
+	}
+
+	jsonStr, jsonErr := err.ToJSON()
+	if jsonErr != nil {
+		t.Fatalf(unexpectedErrFn, jsonErr)
+	}
+
+	if strings.Contains(jsonStr, "schema_version") {
+		t.Errorf("expected the defaults to apply to ToJSON, got %s", jsonStr)
+	}
+
+	group := NewErrorGroup()
+	group.Add(err)
+
+	if stack := group.ToSerialization().Errors[0].StackTrace; len(stack) != 0 {
+		t.Errorf("expected the defaults to apply to groups, got %d frames", len(stack))
+	}
+
+	SetDefaultFormatOptions()
+
+	if err.ToErrorOutput().Stack == "" {
+		t.Error("expected the stack back after clearing the defaults")
+	}
+}
+
+//nolint:paralleltest // mutates the package-level default format options
+func TestPerCallStackTraceOverridesDefault(t *testing.T) {
+	SetDefaultFormatOptions(WithStackTrace(false))
+	t.Cleanup(func() { SetDefaultFormatOptions() })
+
+	err := New(msgTest)
+
+	if output := err.ToErrorOutput(WithStackTrace(true)); output.Stack != err.Stack() {
+		t.Errorf("expected the per-call WithStackTrace(true) to restore the stack, got %q", output.Stack)
+	}
+
+	jsonStr, jsonErr := err.ToJSON(WithStackTrace(true))
+	if jsonErr != nil {
+		t.Fatalf(unexpectedErrFn, jsonErr)
+	}
+
+	if strings.Contains(jsonStr, `"stack":""`) {
+		t.Errorf("expected a stack in JSON, got %s", jsonStr)
+	}
+
+	limited := err.ToErrorOutput(WithMaxStackFrames(1), WithStackTrace(true))
+	if want := truncateStackFrames(err.Stack(), 1) + "\n"; limited.Stack != want {
+		t.Errorf("restored stack must keep the frame limit: got %q, want %q", limited.Stack, want)
+	}
+
+	group := NewErrorGroup()
+	group.Add(err)
+
+	if stack := group.ToSerialization(WithStackTrace(true)).Errors[0].StackTrace; len(stack) == 0 {
+		t.Error("expected the per-call option to restore group stacks")
+	}
+}
@@ -63,7 +63,7 @@ func (e *Error) LogValue() slog.Value {
 	e.mu.RLock()
 
 	for k, v := range e.metadata {
-		attrs = append(attrs, slog.Any(k, v))
+		attrs = append(attrs, slog.Any(k, resolveMetadata(v)))
 	}
 
 	e.mu.RUnlock()
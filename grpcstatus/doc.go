@@ -0,0 +1,8 @@
+// Package grpcstatus gives ewrap errors a gRPC status. Error wraps an error
+// in a value implementing GRPCStatus, the method status.FromError and
+// status.Code look for, so a handler can return it directly; the unary
+// interceptor applies Error to every handler error.
+//
+// The method cannot live on *ewrap.Error without making the core module
+// depend on grpc, so it lives here, in a separate module.
+package grpcstatus
@@ -0,0 +1,20 @@
+module github.com/hyp3rd/ewrap/grpcstatus
+
+go 1.26.4
+
+require (
+	github.com/hyp3rd/ewrap v0.0.0-00010101000000-000000000000
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260720211330-0afa2a65878a
+	google.golang.org/grpc v1.82.1
+)
+
+require (
+	github.com/goccy/go-json v0.10.6 // indirect
+	golang.org/x/net v0.53.0 // indirect
+	golang.org/x/sys v0.43.0 // indirect
+	golang.org/x/text v0.36.0 // indirect
+	google.golang.org/protobuf v1.36.11 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/hyp3rd/ewrap => ../
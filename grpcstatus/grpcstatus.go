@@ -0,0 +1,151 @@
+package grpcstatus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hyp3rd/ewrap"
+)
+
+// codeByType maps each ewrap error type to the closest gRPC code.
+//
+//nolint:gochecknoglobals // read-only lookup table
+var codeByType = map[ewrap.ErrorType]codes.Code{
+	ewrap.ErrorTypeValidation:    codes.InvalidArgument,
+	ewrap.ErrorTypeNotFound:      codes.NotFound,
+	ewrap.ErrorTypePermission:    codes.PermissionDenied,
+	ewrap.ErrorTypeDatabase:      codes.Internal,
+	ewrap.ErrorTypeNetwork:       codes.Unavailable,
+	ewrap.ErrorTypeConfiguration: codes.FailedPrecondition,
+	ewrap.ErrorTypeInternal:      codes.Internal,
+	ewrap.ErrorTypeExternal:      codes.Unavailable,
+}
+
+// Code returns the gRPC code for an ewrap error type, or codes.Unknown for
+// ErrorTypeUnknown and types without a mapping.
+func Code(t ewrap.ErrorType) codes.Code {
+	if code, ok := codeByType[t]; ok {
+		return code
+	}
+
+	return codes.Unknown
+}
+
+// Status converts err into a gRPC status. For an ewrap chain the code comes
+// from the first ErrorContext type, the message is SafeError so redacted
+// text is what crosses the wire, and an ErrorInfo detail carries the ewrap
+// code as its reason and the metadata as strings. A chain with no type
+// keeps the code of a gRPC status error it wraps, if any. Other errors are
+// converted with status.Convert. It returns nil for a nil error.
+func Status(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+
+	var ewrapErr *ewrap.Error
+	if !errors.As(err, &ewrapErr) {
+		return status.Convert(err)
+	}
+
+	code := Code(errorType(err))
+	if code == codes.Unknown {
+		if inner, ok := status.FromError(err); ok {
+			code = inner.Code()
+		}
+	}
+
+	st := status.New(code, ewrapErr.SafeError())
+
+	info := errorInfo(ewrapErr)
+	if info == nil {
+		return st
+	}
+
+	detailed, detailErr := st.WithDetails(info)
+	if detailErr != nil {
+		return st
+	}
+
+	return detailed
+}
+
+// Error wraps err so that status.FromError and status.Code report the
+// status Status builds for it. errors.Is and errors.As still reach err
+// through Unwrap. It returns nil for a nil error.
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &statusError{err: err}
+}
+
+// UnaryServerInterceptor returns an interceptor that passes every handler
+// error through Error, so handlers can keep returning *ewrap.Error values.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+
+		return resp, Error(err)
+	}
+}
+
+// statusError carries an error to gRPC, which finds its status through the
+// GRPCStatus method.
+type statusError struct {
+	err error
+}
+
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+func (e *statusError) Unwrap() error {
+	return e.err
+}
+
+// GRPCStatus implements the interface status.FromError looks for.
+func (e *statusError) GRPCStatus() *status.Status {
+	return Status(e.err)
+}
+
+// errorType returns the type of the first ErrorContext in err's chain.
+func errorType(err error) ewrap.ErrorType {
+	for cur := err; cur != nil; cur = errors.Unwrap(cur) {
+		if e, ok := cur.(*ewrap.Error); ok {
+			if ctx := e.GetErrorContext(); ctx != nil {
+				return ctx.Type
+			}
+		}
+	}
+
+	return ewrap.ErrorTypeUnknown
+}
+
+// errorInfo builds the ErrorInfo detail for e, or nil when it has neither
+// a code nor metadata.
+func errorInfo(e *ewrap.Error) *errdetails.ErrorInfo {
+	metadata := e.Metadata()
+	code := e.Code()
+
+	if code == "" && len(metadata) == 0 {
+		return nil
+	}
+
+	info := &errdetails.ErrorInfo{
+		Reason:   code.String(),
+		Metadata: make(map[string]string, len(metadata)),
+	}
+
+	for key, val := range metadata {
+		info.Metadata[key] = fmt.Sprint(val)
+	}
+
+	return info
+}
@@ -0,0 +1,173 @@
+package grpcstatus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hyp3rd/ewrap"
+)
+
+var errConnRefused = errors.New("connection refused")
+
+func TestErrorMapsTypeToCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		errorType ewrap.ErrorType
+		want      codes.Code
+	}{
+		{ewrap.ErrorTypeValidation, codes.InvalidArgument},
+		{ewrap.ErrorTypeNotFound, codes.NotFound},
+		{ewrap.ErrorTypePermission, codes.PermissionDenied},
+		{ewrap.ErrorTypeDatabase, codes.Internal},
+		{ewrap.ErrorTypeNetwork, codes.Unavailable},
+		{ewrap.ErrorTypeConfiguration, codes.FailedPrecondition},
+		{ewrap.ErrorTypeInternal, codes.Internal},
+		{ewrap.ErrorTypeExternal, codes.Unavailable},
+		{ewrap.ErrorTypeUnknown, codes.Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.errorType.String(), func(t *testing.T) {
+			t.Parallel()
+
+			err := Error(ewrap.New("request failed", ewrap.WithType(tt.errorType)))
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("status.Code: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorTypeFromInnerLayer(t *testing.T) {
+	t.Parallel()
+
+	inner := ewrap.New("user missing", ewrap.WithType(ewrap.ErrorTypeNotFound))
+	outer := ewrap.Wrap(inner, "loading profile")
+
+	if got := status.Code(Error(outer)); got != codes.NotFound {
+		t.Errorf("status.Code: got %v, want %v", got, codes.NotFound)
+	}
+}
+
+func TestStatusMessageAndDetails(t *testing.T) {
+	t.Parallel()
+
+	err := ewrap.New("lookup of alice@example.com failed",
+		ewrap.WithType(ewrap.ErrorTypeNotFound),
+		ewrap.WithSafeMessage("lookup failed"),
+		ewrap.WithCode("USER_NOT_FOUND"),
+	).WithMetadata("attempt", 2)
+
+	st := status.Convert(Error(err))
+
+	if st.Message() != "lookup failed" {
+		t.Errorf("message must be the safe message, got %q", st.Message())
+	}
+
+	details := st.Details()
+	if len(details) != 1 {
+		t.Fatalf("expected one detail, got %d", len(details))
+	}
+
+	info, ok := details[0].(*errdetails.ErrorInfo)
+	if !ok {
+		t.Fatalf("expected *errdetails.ErrorInfo, got %T", details[0])
+	}
+
+	if info.GetReason() != "USER_NOT_FOUND" {
+		t.Errorf("reason: got %q, want %q", info.GetReason(), "USER_NOT_FOUND")
+	}
+
+	if info.GetMetadata()["attempt"] != "2" {
+		t.Errorf("metadata: got %v", info.GetMetadata())
+	}
+}
+
+func TestStatusWithoutCodeOrMetadataHasNoDetails(t *testing.T) {
+	t.Parallel()
+
+	st := Status(ewrap.New("boom", ewrap.WithType(ewrap.ErrorTypeInternal)))
+	if len(st.Details()) != 0 {
+		t.Errorf("expected no details, got %v", st.Details())
+	}
+}
+
+func TestStatusKeepsWrappedGRPCCode(t *testing.T) {
+	t.Parallel()
+
+	downstream := status.Error(codes.ResourceExhausted, "quota exceeded")
+	err := ewrap.Wrap(downstream, "calling billing")
+
+	if got := status.Code(Error(err)); got != codes.ResourceExhausted {
+		t.Errorf("status.Code: got %v, want %v", got, codes.ResourceExhausted)
+	}
+
+	typed := ewrap.Wrap(downstream, "calling billing", ewrap.WithType(ewrap.ErrorTypeExternal))
+	if got := status.Code(Error(typed)); got != codes.Unavailable {
+		t.Errorf("an ewrap type must win over the wrapped code, got %v", got)
+	}
+}
+
+func TestErrorNonEwrap(t *testing.T) {
+	t.Parallel()
+
+	if got := status.Code(Error(errConnRefused)); got != codes.Unknown {
+		t.Errorf("status.Code: got %v, want %v", got, codes.Unknown)
+	}
+
+	if Error(nil) != nil || Status(nil) != nil {
+		t.Error("a nil error must stay nil")
+	}
+}
+
+func TestErrorUnwraps(t *testing.T) {
+	t.Parallel()
+
+	cause := ewrap.Wrap(errConnRefused, "dialing", ewrap.WithType(ewrap.ErrorTypeNetwork))
+	err := Error(cause)
+
+	if !errors.Is(err, errConnRefused) {
+		t.Error("errors.Is must reach the original cause")
+	}
+
+	var ewrapErr *ewrap.Error
+	if !errors.As(err, &ewrapErr) || ewrapErr != cause {
+		t.Error("errors.As must reach the ewrap error")
+	}
+
+	if err.Error() != cause.Error() {
+		t.Errorf("Error: got %q, want %q", err.Error(), cause.Error())
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	t.Parallel()
+
+	interceptor := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/users.Users/Get"}
+
+	failing := func(context.Context, any) (any, error) {
+		return nil, ewrap.New("user missing", ewrap.WithType(ewrap.ErrorTypeNotFound))
+	}
+
+	_, err := interceptor(context.Background(), nil, info, failing)
+	if got := status.Code(err); got != codes.NotFound {
+		t.Errorf("status.Code: got %v, want %v", got, codes.NotFound)
+	}
+
+	succeeding := func(context.Context, any) (any, error) {
+		return "ok", nil
+	}
+
+	resp, err := interceptor(context.Background(), nil, info, succeeding)
+	if err != nil || resp != "ok" {
+		t.Errorf("got %v, %v; want ok, nil", resp, err)
+	}
+}
@@ -132,3 +132,115 @@ func FuzzJSONRoundTrip(f *testing.F) {
 		}
 	})
 }
+
+// chainLength counts the layers reachable via errors.Unwrap.
+func chainLength(err error) int {
+	n := 0
+	for cur := err; cur != nil; cur = errors.Unwrap(cur) {
+		n++
+	}
+
+	return n
+}
+
+// TestMaxWrapDepthDefault verifies that the default guard bounds the chain
+// without losing messages or the root.
+func TestMaxWrapDepthDefault(t *testing.T) {
+	t.Parallel()
+
+	err := errRoot
+	for i := range deepChainDepth {
+		err = Wrap(err, fmt.Sprintf("layer-%d", i))
+	}
+
+	if got := chainLength(err); got != defaultMaxWrapDepth+1 {
+		t.Errorf("chain length: got %d, want %d", got, defaultMaxWrapDepth+1)
+	}
+
+	for _, want := range []string{"layer-0: ", fmt.Sprintf("layer-%d: ", deepChainDepth-1), msgRoot} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q to survive collapsing", want)
+		}
+	}
+
+	if !errors.Is(err, errRoot) {
+		t.Error("errors.Is must still find the root after collapsing")
+	}
+}
+
+//nolint:paralleltest // mutates the package-level wrap depth limit
+func TestSetMaxWrapDepth(t *testing.T) {
+	t.Cleanup(func() { maxWrapDepth.Store(0) })
+
+	const limit = 3
+
+	SetMaxWrapDepth(limit)
+
+	err := New(msgRoot)
+	for i := range limit * 2 {
+		err = Wrap(err, fmt.Sprintf("layer-%d", i))
+	}
+
+	if got := chainLength(err); got != limit+1 {
+		t.Errorf("chain length: got %d, want %d", got, limit+1)
+	}
+
+	const want = "layer-5: layer-4: layer-3: layer-2: layer-1: layer-0: root"
+	if got := err.Error(); got != want {
+		t.Errorf("message: got %q, want %q", got, want)
+	}
+
+	SetMaxWrapDepth(0)
+
+	for i := range deepChainDepth {
+		err = Wrap(err, fmt.Sprintf("more-%d", i))
+	}
+
+	if got := chainLength(err); got != limit+1+deepChainDepth {
+		t.Errorf("expected no limit after SetMaxWrapDepth(0), got chain length %d", got)
+	}
+}
+
+//nolint:paralleltest // mutates the package-level metadata cap
+func TestSetMaxMetadataEntries(t *testing.T) {
+	t.Cleanup(func() { SetMaxMetadataEntries(0) })
+
+	const limit = 5
+
+	SetMaxMetadataEntries(limit)
+
+	obs := &warningObserver{}
+	err := New(msgTest, WithObserver(obs))
+
+	for i := range smallStringLength {
+		err.WithMetadata(fmt.Sprintf("key-%d", i), i)
+	}
+
+	err.WithMetadataMap(map[string]any{"bulk-1": 1, "bulk-2": 2})
+
+	if got := len(err.Metadata()); got != limit {
+		t.Fatalf("metadata size: got %d, want %d", got, limit)
+	}
+
+	if got, want := err.DroppedMetadata(), smallStringLength-limit+2; got != want {
+		t.Errorf("dropped: got %d, want %d", got, want)
+	}
+
+	if len(obs.warnings) != smallStringLength-limit+1 {
+		t.Errorf("warnings: got %d, want %d", len(obs.warnings), smallStringLength-limit+1)
+	}
+
+	// Existing keys can still be overwritten at the cap.
+	err.WithMetadata("key-0", msgValue)
+
+	if got, _ := err.GetMetadata("key-0"); got != msgValue {
+		t.Errorf("overwrite at cap: got %v, want %q", got, msgValue)
+	}
+
+	SetMaxMetadataEntries(0)
+	err.WithMetadata(msgKey, msgValue)
+
+	if got := len(err.Metadata()); got != limit+1 {
+		t.Errorf("expected no cap after SetMaxMetadataEntries(0), got %d entries", got)
+	}
+}
@@ -0,0 +1,91 @@
+package ewrap
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// hashStackFrames is the number of top stack frames Hash considers.
+const hashStackFrames = 3
+
+// FingerprintField selects an input to (*Error).Hash. Fields combine with
+// bitwise OR.
+type FingerprintField uint8
+
+const (
+	// FingerprintMessages hashes the message of every layer of the chain.
+	FingerprintMessages FingerprintField = 1 << iota
+	// FingerprintCode hashes the code attached with WithCode.
+	FingerprintCode
+	// FingerprintStack hashes the function and line of the top few stack
+	// frames. File paths are left out, so builds in different directories
+	// hash alike.
+	FingerprintStack
+	// FingerprintHTTPStatus hashes the HTTP status attached with
+	// WithHTTPStatus.
+	FingerprintHTTPStatus
+
+	// DefaultFingerprintFields is what Hash uses unless the error was given
+	// WithFingerprintFields.
+	DefaultFingerprintFields = FingerprintMessages | FingerprintCode | FingerprintHTTPStatus | FingerprintStack
+)
+
+// WithFingerprintFields restricts the inputs Hash considers for this error
+// and for errors that wrap it. Calling it with no fields restores
+// DefaultFingerprintFields.
+func WithFingerprintFields(fields ...FingerprintField) Option {
+	return func(err *Error) {
+		var combined FingerprintField
+		for _, field := range fields {
+			combined |= field
+		}
+
+		err.fingerprint = combined
+	}
+}
+
+// Hash returns a stable hex fingerprint for grouping occurrences of the same
+// error, as aggregation dashboards do. By default it covers the message
+// chain, the code, the HTTP status and the function:line of the top stack
+// frames, and
+// deliberately ignores volatile data: timestamps, metadata and file paths.
+// Two errors raised from the same code path with the same messages hash
+// identically. Use WithFingerprintFields to narrow the inputs.
+func (e *Error) Hash() string {
+	fields := e.fingerprint
+	if fields == 0 {
+		fields = DefaultFingerprintFields
+	}
+
+	h := sha256.New()
+
+	// Each input is NUL-terminated so adjacent values cannot run together.
+	write := func(s string) {
+		_, _ = h.Write([]byte(s))
+		_, _ = h.Write([]byte{0})
+	}
+
+	if fields&FingerprintMessages != 0 {
+		for _, msg := range e.Causes() {
+			write(msg)
+		}
+	}
+
+	if fields&FingerprintCode != 0 {
+		write(e.Code().String())
+	}
+
+	if fields&FingerprintHTTPStatus != 0 {
+		write(strconv.Itoa(HTTPStatus(e)))
+	}
+
+	if fields&FingerprintStack != 0 {
+		frames := e.GetStackIterator().Frames()
+		for _, frame := range frames[:min(len(frames), hashStackFrames)] {
+			write(frame.Function + ":" + strconv.Itoa(frame.Line))
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
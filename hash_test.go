@@ -0,0 +1,79 @@
+package ewrap
+
+import (
+	"net/http"
+	"testing"
+)
+
+// raiseForHash builds an error from one fixed call site.
+//
+//go:noinline
+func raiseForHash(msg string, opts ...Option) *Error {
+	return Wrap(New(msgRootCause), msg, opts...)
+}
+
+func TestErrorHash(t *testing.T) {
+	t.Parallel()
+
+	// Raise every error from the same line so only the inputs differ.
+	messages := []string{msgWrapped, msgWrapped, msgOriginal}
+	hashes := make([]string, len(messages))
+
+	for i, msg := range messages {
+		hashes[i] = raiseForHash(msg).WithMetadata("request", i).Hash()
+	}
+
+	if hashes[0] != hashes[1] {
+		t.Error("errors from the same code path must hash identically")
+	}
+
+	if hashes[0] == hashes[2] {
+		t.Error("different messages must hash differently")
+	}
+
+	first := raiseForHash(msgWrapped)
+	if len(first.Hash()) != 64 {
+		t.Errorf("expected a hex SHA-256, got %q", first.Hash())
+	}
+
+	if raiseForHash(msgWrapped, WithHTTPStatus(http.StatusNotFound)).Hash() == first.Hash() {
+		t.Error("different HTTP statuses must hash differently")
+	}
+
+	if raiseForHash(msgWrapped, WithCode(codeNotFound)).Hash() == first.Hash() {
+		t.Error("different codes must hash differently")
+	}
+
+	elsewhere := Wrap(New(msgRootCause), msgWrapped)
+	if elsewhere.Hash() == first.Hash() {
+		t.Error("different call sites must hash differently by default")
+	}
+}
+
+func TestWithFingerprintFields(t *testing.T) {
+	t.Parallel()
+
+	messagesOnly := WithFingerprintFields(FingerprintMessages)
+
+	here := raiseForHash(msgWrapped, messagesOnly)
+	there := Wrap(New(msgRootCause), msgWrapped, messagesOnly, WithHTTPStatus(http.StatusTeapot))
+
+	if here.Hash() != there.Hash() {
+		t.Error("with messages only, call site and HTTP status must not matter")
+	}
+
+	codeOnly := WithFingerprintFields(FingerprintCode)
+	if raiseForHash(msgWrapped, codeOnly, WithHTTPStatus(http.StatusTeapot)).Hash() !=
+		raiseForHash(msgOriginal, codeOnly).Hash() {
+		t.Error("with the code only, messages and HTTP status must not matter")
+	}
+
+	if Wrap(here, msgWrapped).fingerprint != FingerprintMessages {
+		t.Error("fingerprint fields must be inherited by Wrap")
+	}
+
+	reset := New(msgTest, WithFingerprintFields(FingerprintMessages), WithFingerprintFields())
+	if reset.fingerprint != 0 {
+		t.Error("WithFingerprintFields() must restore the default")
+	}
+}
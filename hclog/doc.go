@@ -0,0 +1,4 @@
+// Package hclog provides an adapter that lets a HashiCorp go-hclog logger
+// satisfy the ewrap.Logger interface. It is a separate module, so go-hclog
+// is not a dependency of the core ewrap module.
+package hclog
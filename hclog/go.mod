@@ -0,0 +1,17 @@
+module github.com/hyp3rd/ewrap/hclog
+
+go 1.26.4
+
+require (
+	github.com/hashicorp/go-hclog v1.6.3
+	github.com/hyp3rd/ewrap v0.0.0-00010101000000-000000000000
+)
+
+require (
+	github.com/fatih/color v1.13.0 // indirect
+	github.com/mattn/go-colorable v0.1.12 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	golang.org/x/sys v0.30.0 // indirect
+)
+
+replace github.com/hyp3rd/ewrap => ../
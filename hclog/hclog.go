@@ -0,0 +1,34 @@
+package hclog
+
+import (
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/hyp3rd/ewrap/internal/kv"
+)
+
+// Adapter wraps an hclog.Logger so it can be passed to ewrap.WithLogger.
+// Malformed pairs are dropped rather than logged under hclog's
+// EXTRA_VALUE_AT_END key.
+type Adapter struct {
+	logger hclog.Logger
+}
+
+// New returns an Adapter backed by logger.
+func New(logger hclog.Logger) *Adapter {
+	return &Adapter{logger: logger}
+}
+
+// Error logs an error message with optional key-value pairs.
+func (a *Adapter) Error(msg string, keysAndValues ...any) {
+	a.logger.Error(msg, kv.Pairs(keysAndValues)...)
+}
+
+// Debug logs a debug message with optional key-value pairs.
+func (a *Adapter) Debug(msg string, keysAndValues ...any) {
+	a.logger.Debug(msg, kv.Pairs(keysAndValues)...)
+}
+
+// Info logs an info message with optional key-value pairs.
+func (a *Adapter) Info(msg string, keysAndValues ...any) {
+	a.logger.Info(msg, kv.Pairs(keysAndValues)...)
+}
@@ -0,0 +1,80 @@
+package hclog
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+func newBufferedAdapter() (*Adapter, *bytes.Buffer) {
+	var buf bytes.Buffer
+
+	logger := hclog.New(&hclog.LoggerOptions{
+		Output:     &buf,
+		Level:      hclog.Debug,
+		JSONFormat: true,
+	})
+
+	return New(logger), &buf
+}
+
+func decodeRecord(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("output is not a JSON record: %v\n%s", err, buf.String())
+	}
+
+	return record
+}
+
+func TestAdapter(t *testing.T) {
+	t.Parallel()
+
+	adapter, buf := newBufferedAdapter()
+
+	cases := []struct {
+		level string
+		msg   string
+		key   string
+		emit  func()
+	}{
+		{level: "error", msg: "error message", key: "key1", emit: func() { adapter.Error("error message", "key1", "value1") }},
+		{level: "debug", msg: "debug message", key: "key2", emit: func() { adapter.Debug("debug message", "key2", "value2") }},
+		{level: "info", msg: "info message", key: "key3", emit: func() { adapter.Info("info message", "key3", "value3") }},
+	}
+
+	for _, tc := range cases {
+		buf.Reset()
+		tc.emit()
+
+		record := decodeRecord(t, buf)
+		if record["@level"] != tc.level || record["@message"] != tc.msg {
+			t.Errorf("got %v %q, want %v %q", record["@level"], record["@message"], tc.level, tc.msg)
+		}
+
+		if record[tc.key] == nil {
+			t.Errorf("expected structured field %q, got %v", tc.key, record)
+		}
+	}
+}
+
+func TestAdapterOddArgs(t *testing.T) {
+	t.Parallel()
+
+	adapter, buf := newBufferedAdapter()
+
+	adapter.Error("error message", "key", "value", "dangling")
+
+	record := decodeRecord(t, buf)
+	if record["key"] != "value" {
+		t.Errorf("expected the well-formed pair, got %v", record)
+	}
+
+	if _, ok := record[hclog.MissingKey]; ok {
+		t.Errorf("trailing key should be dropped, got %v", record)
+	}
+}
@@ -0,0 +1,47 @@
+// Package kv normalizes the variadic key-value pairs passed to ewrap.Logger
+// methods for the logger adapters, so every adapter drops malformed pairs
+// the same way.
+package kv
+
+// Pairs returns keysAndValues without the pairs whose key is not a string
+// and without a trailing key that has no value. A well-formed list is
+// returned as is, without copying.
+func Pairs(keysAndValues []any) []any {
+	if wellFormed(keysAndValues) {
+		return keysAndValues
+	}
+
+	out := make([]any, 0, len(keysAndValues))
+
+	Each(keysAndValues, func(key string, value any) {
+		out = append(out, key, value)
+	})
+
+	return out
+}
+
+// Each calls fn for every pair in keysAndValues whose key is a string. A
+// trailing key without a value is skipped.
+func Each(keysAndValues []any, fn func(key string, value any)) {
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		if key, ok := keysAndValues[i].(string); ok {
+			fn(key, keysAndValues[i+1])
+		}
+	}
+}
+
+// wellFormed reports whether keysAndValues is an even-length list with a
+// string in every key position.
+func wellFormed(keysAndValues []any) bool {
+	if len(keysAndValues)%2 != 0 {
+		return false
+	}
+
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if _, ok := keysAndValues[i].(string); !ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,54 @@
+package kv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPairs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   []any
+		want []any
+	}{
+		{"empty", nil, nil},
+		{"well formed", []any{"a", 1, "b", 2}, []any{"a", 1, "b", 2}},
+		{"trailing key", []any{"a", 1, "b"}, []any{"a", 1}},
+		{"non-string key", []any{"a", 1, 42, "x", "b", 2}, []any{"a", 1, "b", 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := Pairs(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Pairs(%v) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPairsReturnsWellFormedInput(t *testing.T) {
+	t.Parallel()
+
+	in := []any{"a", 1}
+	if got := Pairs(in); &got[0] != &in[0] {
+		t.Error("a well-formed list must be returned without copying")
+	}
+}
+
+func TestEach(t *testing.T) {
+	t.Parallel()
+
+	got := map[string]any{}
+
+	Each([]any{"a", 1, 42, "x", "b"}, func(key string, value any) {
+		got[key] = value
+	})
+
+	if want := map[string]any{"a": 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Each collected %v, want %v", got, want)
+	}
+}
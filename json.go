@@ -0,0 +1,221 @@
+package ewrap
+
+import (
+	stdjson "encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+// JSONMarshaler encodes values to JSON. Every JSON path in the package —
+// (*Error).ToJSON and the ErrorGroup serializers — goes through the
+// marshaler installed with SetJSONMarshaler, so output is consistent
+// regardless of entry point.
+type JSONMarshaler interface {
+	// Marshal returns the compact JSON encoding of v.
+	Marshal(v any) ([]byte, error)
+	// MarshalIndent is like Marshal but applies prefix and indent.
+	MarshalIndent(v any, prefix, indent string) ([]byte, error)
+}
+
+// GoccyJSON is the default JSONMarshaler, backed by github.com/goccy/go-json.
+type GoccyJSON struct{}
+
+// Marshal returns the compact JSON encoding of v.
+func (GoccyJSON) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// MarshalIndent is like Marshal but applies prefix and indent.
+func (GoccyJSON) MarshalIndent(v any, prefix, indent string) ([]byte, error) {
+	return json.MarshalIndent(v, prefix, indent)
+}
+
+// StdlibJSON is a JSONMarshaler backed by the standard library's
+// encoding/json, for callers who prefer its exact behavior over speed.
+type StdlibJSON struct{}
+
+// Marshal returns the compact JSON encoding of v.
+func (StdlibJSON) Marshal(v any) ([]byte, error) {
+	return stdjson.Marshal(v)
+}
+
+// MarshalIndent is like Marshal but applies prefix and indent.
+func (StdlibJSON) MarshalIndent(v any, prefix, indent string) ([]byte, error) {
+	return stdjson.MarshalIndent(v, prefix, indent)
+}
+
+// jsonMarshalerBox lets atomic.Pointer hold interface values of differing
+// concrete types.
+type jsonMarshalerBox struct {
+	marshaler JSONMarshaler
+}
+
+//nolint:gochecknoglobals // package-wide encoder selection, swapped atomically
+var activeJSONMarshaler atomic.Pointer[jsonMarshalerBox]
+
+// SetJSONMarshaler installs m as the package-wide JSON encoder. Passing nil
+// restores the default (GoccyJSON). It is safe to call concurrently with
+// serialization, but is intended to be configured once at startup.
+func SetJSONMarshaler(m JSONMarshaler) {
+	if m == nil {
+		activeJSONMarshaler.Store(nil)
+
+		return
+	}
+
+	activeJSONMarshaler.Store(&jsonMarshalerBox{marshaler: m})
+}
+
+// jsonMarshaler returns the active JSON encoder.
+func jsonMarshaler() JSONMarshaler {
+	if box := activeJSONMarshaler.Load(); box != nil {
+		return box.marshaler
+	}
+
+	return GoccyJSON{}
+}
+
+// MarshalJSON implements json.Marshaler with the same output as ToJSON,
+// compacted, so an *Error embedded in a larger struct encodes usefully
+// instead of as an empty object.
+func (e *Error) MarshalJSON() ([]byte, error) {
+	data, err := jsonMarshaler().Marshal(e.ToErrorOutput().marshalTarget())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal error to JSON: %w", err)
+	}
+
+	return data, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, rebuilding the error from the
+// output of MarshalJSON or ToJSON: messages, cause chain, timestamps,
+// context, metadata, tags, code and recovery suggestion. Causes, including
+// ones that were not ewrap errors, come back as *Error values with the same
+// text. Stacks cannot be restored and are cleared. The HTTP status, retry
+// information and other attributes that ErrorOutput does not carry
+// are left unchanged. JSON numbers in metadata and context data decode as
+// float64.
+func (e *Error) UnmarshalJSON(data []byte) error {
+	var output ErrorOutput
+	if err := json.Unmarshal(data, &output); err != nil {
+		return fmt.Errorf("failed to unmarshal error from JSON: %w", err)
+	}
+
+	decoded := fromErrorOutput(&output)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.msg = decoded.msg
+	e.fullMsg = decoded.fullMsg
+	e.cause = decoded.cause
+	e.depth = decoded.depth
+	e.createdAt = decoded.createdAt
+	e.errorContext = decoded.errorContext
+	e.ownsContext = false
+	e.metadata = decoded.metadata
+	e.recovery = decoded.recovery
+	e.tags = decoded.tags
+	e.code = decoded.code
+	e.stack = nil
+	e.errText.Store(nil)
+	e.stackOnce = sync.Once{}
+	e.stackStr = ""
+
+	return nil
+}
+
+// fromErrorOutput rebuilds an *Error, and its cause chain, from output.
+// Nodes without a timestamp were rendered from non-ewrap errors, whose
+// message already includes their cause's text.
+func fromErrorOutput(output *ErrorOutput) *Error {
+	decoded := &Error{
+		msg:      output.Message,
+		fullMsg:  output.Timestamp == "",
+		metadata: output.Metadata,
+		recovery: output.Recovery,
+		tags:     output.Tags,
+		code:     Code(output.Code),
+	}
+
+	if created, err := time.Parse(time.RFC3339, output.Timestamp); err == nil {
+		decoded.createdAt = created
+	}
+
+	if output.Context != nil {
+		decoded.errorContext = errorContextFromOutput(output, decoded.createdAt)
+	}
+
+	if output.Cause != nil {
+		cause := fromErrorOutput(output.Cause)
+		decoded.cause = cause
+		decoded.depth = cause.depth + 1
+	}
+
+	return decoded
+}
+
+// errorContextFromOutput rebuilds an ErrorContext from the type, severity
+// and context map of a serialized error.
+func errorContextFromOutput(output *ErrorOutput, created time.Time) *ErrorContext {
+	fields := output.Context
+	str := func(key string) string {
+		s, _ := fields[key].(string)
+
+		return s
+	}
+
+	ctx := &ErrorContext{
+		Timestamp:   created,
+		Type:        parseErrorType(output.Type),
+		Severity:    parseSeverity(output.Severity),
+		RequestID:   str("request_id"),
+		User:        str("user"),
+		Component:   str("component"),
+		Operation:   str("operation"),
+		File:        str("file"),
+		Environment: str("environment"),
+		Version:     str("version"),
+	}
+
+	if line, ok := fields["line"].(float64); ok {
+		ctx.Line = int(line)
+	}
+
+	ctx.TimedOut, _ = fields["timed_out"].(bool)
+	ctx.Data, _ = fields["data"].(map[string]any)
+
+	if deadline, err := time.Parse(time.RFC3339Nano, str("deadline")); err == nil {
+		ctx.Deadline = deadline
+	}
+
+	return ctx
+}
+
+// parseErrorType is the inverse of ErrorType.String; unrecognized names
+// map to ErrorTypeUnknown.
+func parseErrorType(name string) ErrorType {
+	for t := ErrorTypeUnknown; t <= ErrorTypeExternal; t++ {
+		if t.String() == name {
+			return t
+		}
+	}
+
+	return ErrorTypeUnknown
+}
+
+// parseSeverity is the inverse of Severity.String; unrecognized names map
+// to SeverityError, the default severity.
+func parseSeverity(name string) Severity {
+	for s := SeverityInfo; s <= SeverityCritical; s++ {
+		if s.String() == name {
+			return s
+		}
+	}
+
+	return SeverityError
+}
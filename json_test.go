@@ -0,0 +1,172 @@
+package ewrap
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+// countingMarshaler delegates to StdlibJSON and counts invocations.
+type countingMarshaler struct {
+	StdlibJSON
+
+	calls atomic.Int64
+}
+
+func (c *countingMarshaler) MarshalIndent(v any, prefix, indent string) ([]byte, error) {
+	c.calls.Add(1)
+
+	return c.StdlibJSON.MarshalIndent(v, prefix, indent)
+}
+
+func (c *countingMarshaler) Marshal(v any) ([]byte, error) {
+	c.calls.Add(1)
+
+	return c.StdlibJSON.Marshal(v)
+}
+
+func TestJSONMarshalersProduceEquivalentOutput(t *testing.T) {
+	t.Parallel()
+
+	err := Wrap(
+		New(msgRoot, WithRecoverySuggestion(&RecoverySuggestion{
+			Message: "retry later",
+			Actions: []string{"wait", "retry"},
+		})),
+		msgWrapped,
+		WithContext(context.Background(), ErrorTypeDatabase, SeverityCritical),
+	).WithMetadataMap(map[string]any{
+		"query":    "SELECT 1",
+		"attempts": defaultMaxAttempts,
+		"html":     "<b>&</b>",
+	})
+
+	output := err.toErrorOutput()
+
+	goccy, gerr := GoccyJSON{}.MarshalIndent(output, "", "  ")
+	if gerr != nil {
+		t.Fatalf(unexpectedErrFn, gerr)
+	}
+
+	std, serr := StdlibJSON{}.MarshalIndent(output, "", "  ")
+	if serr != nil {
+		t.Fatalf(unexpectedErrFn, serr)
+	}
+
+	if !bytes.Equal(goccy, std) {
+		t.Errorf("encoders disagree:\ngoccy:  %s\nstdlib: %s", goccy, std)
+	}
+}
+
+//nolint:paralleltest // mutates the package-level JSON marshaler
+func TestSetJSONMarshaler(t *testing.T) {
+	counting := &countingMarshaler{}
+
+	SetJSONMarshaler(counting)
+	t.Cleanup(func() { SetJSONMarshaler(nil) })
+
+	if _, err := New(msgTestError).ToJSON(); err != nil {
+		t.Fatalf(unexpectedErrFn, err)
+	}
+
+	eg := NewErrorGroup()
+	eg.Add(errFirst)
+
+	if _, err := eg.ToJSON(); err != nil {
+		t.Fatalf(unexpectedErrFn, err)
+	}
+
+	if _, err := eg.MarshalJSON(); err != nil {
+		t.Fatalf(unexpectedErrFn, err)
+	}
+
+	if got := counting.calls.Load(); got != 3 {
+		t.Errorf("expected every JSON path to use the installed marshaler, got %d calls", got)
+	}
+
+	SetJSONMarshaler(nil)
+
+	if _, ok := jsonMarshaler().(GoccyJSON); !ok {
+		t.Errorf("expected nil to restore the default marshaler, got %T", jsonMarshaler())
+	}
+}
+
+func TestErrorMarshalJSONEmbedded(t *testing.T) {
+	t.Parallel()
+
+	type envelope struct {
+		Status string `json:"status"`
+		Err    *Error `json:"error"`
+	}
+
+	err := Wrap(
+		fmt.Errorf("dialing: %w", errRoot),
+		msgWrapped,
+		WithContext(context.WithValue(context.Background(), "request_id", "req-7"), ErrorTypeNetwork, SeverityCritical),
+		WithTags(msgTest),
+		WithCode(codeConflict),
+		WithRecoverySuggestion(&RecoverySuggestion{Message: "retry later"}),
+	).WithMetadata(msgKey, msgValue)
+
+	data, marshalErr := json.Marshal(envelope{Status: "failed", Err: err})
+	if marshalErr != nil {
+		t.Fatalf(unexpectedErrFn, marshalErr)
+	}
+
+	if !bytes.Contains(data, []byte(`"message":"wrapped"`)) {
+		t.Fatalf("expected the error's fields in the parent object, got %s", data)
+	}
+
+	var decoded envelope
+	if unmarshalErr := json.Unmarshal(data, &decoded); unmarshalErr != nil {
+		t.Fatalf(unexpectedErrFn, unmarshalErr)
+	}
+
+	got := decoded.Err
+	if got == nil || got.Error() != err.Error() {
+		t.Fatalf("round trip changed the message: got %v, want %q", got, err.Error())
+	}
+
+	if causes := got.UnwrapAll(); len(causes) != 3 || causes[2].Error() != msgRoot {
+		t.Errorf("expected the cause chain to survive, got %v", causes)
+	}
+
+	ctx := got.GetErrorContext()
+	if ctx == nil || ctx.Type != ErrorTypeNetwork || ctx.Severity != SeverityCritical || ctx.RequestID != "req-7" {
+		t.Errorf("expected the context to survive, got %+v", ctx)
+	}
+
+	if v, ok := got.GetMetadata(msgKey); !ok || v != msgValue {
+		t.Errorf("expected metadata to survive, got %v, %v", v, ok)
+	}
+
+	if !got.HasTag(msgTest) || got.Recovery() == nil || got.Recovery().Message != "retry later" {
+		t.Error("expected tags and recovery suggestion to survive")
+	}
+
+	if got.Code() != codeConflict {
+		t.Errorf("expected the code to survive, got %q", got.Code())
+	}
+
+	if !got.createdAt.Equal(err.createdAt.Truncate(time.Second)) {
+		t.Errorf("expected the creation time to survive, got %v", got.createdAt)
+	}
+
+	if got.Stack() != "" {
+		t.Error("stacks cannot be restored and should be empty")
+	}
+}
+
+func TestErrorUnmarshalJSONInvalid(t *testing.T) {
+	t.Parallel()
+
+	var err Error
+	if unmarshalErr := json.Unmarshal([]byte(`{"message": 1}`), &err); unmarshalErr == nil {
+		t.Error("expected an error for a malformed payload")
+	}
+}
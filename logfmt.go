@@ -0,0 +1,89 @@
+package ewrap
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ToLogfmt renders the error as a single logfmt line: msg (the full Error()
+// text), type, severity, code (from WithCode) and http_status, each only when
+// set, then metadata in key order, then stack. Values containing spaces, equals signs, quotes or
+// control characters are quoted with Go escaping, so a multi-line stack stays
+// on one line. Format options apply as for ToJSON: WithStackTrace(false)
+// drops the stack field.
+func (e *Error) ToLogfmt(opts ...FormatOption) string {
+	output := e.toErrorOutput(withDefaultFormatOptions(opts)...)
+
+	var builder strings.Builder
+
+	builder.Grow(initialBuilderCapacity)
+
+	writeLogfmtPair(&builder, "msg", e.Error())
+	writeLogfmtPair(&builder, "type", output.Type)
+	writeLogfmtPair(&builder, "severity", output.Severity)
+
+	if code := e.Code(); code != "" {
+		writeLogfmtPair(&builder, "code", code.String())
+	}
+
+	if status := HTTPStatus(e); status != 0 {
+		writeLogfmtPair(&builder, "http_status", strconv.Itoa(status))
+	}
+
+	keys := make([]string, 0, len(output.Metadata))
+	for key := range output.Metadata {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		writeLogfmtPair(&builder, key, fmt.Sprint(output.Metadata[key]))
+	}
+
+	if output.Stack != "" {
+		writeLogfmtPair(&builder, "stack", output.Stack)
+	}
+
+	return builder.String()
+}
+
+// writeLogfmtPair appends key=value, preceded by a space unless it is the
+// first pair. Characters logfmt keys cannot hold are replaced with '_'.
+func writeLogfmtPair(builder *strings.Builder, key, value string) {
+	if builder.Len() > 0 {
+		builder.WriteByte(' ')
+	}
+
+	builder.WriteString(strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' {
+			return '_'
+		}
+
+		return r
+	}, key))
+	builder.WriteByte('=')
+
+	if logfmtNeedsQuoting(value) {
+		builder.WriteString(strconv.Quote(value))
+
+		return
+	}
+
+	builder.WriteString(value)
+}
+
+// logfmtNeedsQuoting reports whether value must be quoted to survive as a
+// single logfmt value.
+func logfmtNeedsQuoting(value string) bool {
+	if value == "" {
+		return true
+	}
+
+	return strings.ContainsFunc(value, func(r rune) bool {
+		return r <= ' ' || r == '=' || r == '"' || r == '\\' || !unicode.IsPrint(r)
+	})
+}
@@ -0,0 +1,54 @@
+package ewrap
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestToLogfmt(t *testing.T) {
+	t.Parallel()
+
+	err := New("payment failed",
+		WithType(ErrorTypeExternal),
+		WithHTTPStatus(http.StatusBadGateway),
+		WithCode(codeConflict),
+	).WithMetadataMap(map[string]any{
+		"provider": "stripe",
+		"query":    "a=b",
+		"attempt":  2,
+		"note":     `say "hi"`,
+		"bad key":  "x",
+	})
+
+	got := err.ToLogfmt(WithStackTrace(false))
+
+	want := `msg="payment failed" type=external severity=error code=` + codeConflict.String() + ` http_status=502 ` +
+		`attempt=2 bad_key=x note="say \"hi\"" provider=stripe query="a=b"`
+	if got != want {
+		t.Errorf("ToLogfmt:\ngot  %s\nwant %s", got, want)
+	}
+}
+
+func TestToLogfmtStack(t *testing.T) {
+	t.Parallel()
+
+	err := New(msgBoom)
+
+	withStack := err.ToLogfmt()
+	if !strings.Contains(withStack, ` stack="`) {
+		t.Fatalf("expected a quoted stack field: %s", withStack)
+	}
+
+	if strings.Contains(withStack, "\n") {
+		t.Errorf("logfmt output must stay on one line: %q", withStack)
+	}
+
+	if strings.Contains(err.ToLogfmt(WithStackTrace(false)), "stack=") {
+		t.Error("WithStackTrace(false) must drop the stack field")
+	}
+
+	if got := err.ToLogfmt(WithStackTrace(false)); got != "msg=boom type=unknown severity=error" {
+		t.Errorf("minimal error: got %s", got)
+	}
+}
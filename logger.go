@@ -1,11 +1,21 @@
 package ewrap
 
+import (
+	"context"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sync/atomic"
+)
+
 // Logger defines the minimal logging interface ewrap depends on. Any logging
-// library can satisfy it with a small adapter; no external logger is bundled.
+// library can satisfy it with a small adapter.
 //
 // Implementations must accept structured key/value pairs as the variadic
-// arguments. Adapters for slog live in subpackages; for zap, zerolog, logrus,
-// users write their own (≤10 lines) and pass them via WithLogger.
+// arguments. Adapters for slog, apex/log, charmbracelet/log and go-hclog live
+// in subpackages; for zap, zerolog, logrus, users write their own (≤10
+// lines) and pass them via WithLogger.
 type Logger interface {
 	// Error logs an error message with optional key-value pairs.
 	Error(msg string, keysAndValues ...any)
@@ -14,3 +24,143 @@ type Logger interface {
 	// Info logs an info message with optional key-value pairs.
 	Info(msg string, keysAndValues ...any)
 }
+
+// NoopLogger is a Logger that discards everything. It is what WithLogger(nil)
+// installs, and is handy in tests and adapters that need a non-nil Logger.
+type NoopLogger struct{}
+
+// Error discards the message.
+func (NoopLogger) Error(string, ...any) {}
+
+// Debug discards the message.
+func (NoopLogger) Debug(string, ...any) {}
+
+// Info discards the message.
+func (NoopLogger) Info(string, ...any) {}
+
+// loggerBox lets atomic.Pointer hold Logger values of differing concrete
+// types.
+type loggerBox struct {
+	logger Logger
+}
+
+//nolint:gochecknoglobals // package-wide default, swapped atomically
+var defaultLogger atomic.Pointer[loggerBox]
+
+// SetDefaultLogger installs l as the logger attached to errors built by New,
+// Newf and Wrap when none is inherited or supplied via WithLogger. Unlike
+// WithLogger, the default does not log an "error created" entry. Passing nil
+// removes the default. Configure it once at startup.
+func SetDefaultLogger(l Logger) {
+	if l == nil {
+		defaultLogger.Store(nil)
+
+		return
+	}
+
+	defaultLogger.Store(&loggerBox{logger: l})
+}
+
+// currentDefaultLogger returns the logger installed by SetDefaultLogger, or
+// nil.
+func currentDefaultLogger() Logger {
+	if box := defaultLogger.Load(); box != nil {
+		return box.logger
+	}
+
+	return nil
+}
+
+//nolint:gochecknoglobals // package-wide logging policy, swapped atomically
+var logSeverityThreshold atomic.Int32
+
+// SetLogSeverityThreshold makes Log a no-op for errors whose severity is
+// below s, keeping info and warning noise out of production logs. Errors
+// without an ErrorContext count as SeverityError. The observer still records
+// suppressed errors, so metrics are unaffected. The default, SeverityInfo,
+// logs everything.
+func SetLogSeverityThreshold(s Severity) {
+	logSeverityThreshold.Store(int32(s)) //nolint:gosec // Severity values are tiny
+}
+
+// belowLogThreshold reports whether Log should skip the logger for s.
+func belowLogThreshold(s Severity) bool {
+	return int32(s) < logSeverityThreshold.Load() //nolint:gosec // Severity values are tiny
+}
+
+// logDropRate holds the float64 bits of 1 - the SetLogSampleRate rate, so the
+// zero value keeps every record.
+//
+//nolint:gochecknoglobals // package-wide logging policy, swapped atomically
+var logDropRate atomic.Uint64
+
+// SetLogSampleRate makes Log emit only about rate of its records, so an
+// incident storm can't overwhelm the logging pipeline. rate is clamped to
+// [0, 1]: 1 (the default) logs everything and 0 logs nothing. Sampling never
+// skips the observer, so error metrics stay exact.
+func SetLogSampleRate(rate float64) {
+	if math.IsNaN(rate) {
+		rate = 1
+	}
+
+	logDropRate.Store(math.Float64bits(1 - min(max(rate, 0), 1)))
+}
+
+// sampledOut reports whether Log should skip the logger for this call.
+func sampledOut() bool {
+	switch drop := math.Float64frombits(logDropRate.Load()); {
+	case drop <= 0:
+		return false
+	case drop >= 1:
+		return true
+	default:
+		// Sampling needs speed, not unpredictability; the runtime-seeded
+		// global source is lock-free.
+		return rand.Float64() < drop //nolint:gosec // non-cryptographic sampling
+	}
+}
+
+// logContextKey pairs a log field name with the context key it is read from.
+type logContextKey struct {
+	field string
+	key   any
+}
+
+//nolint:gochecknoglobals // package-wide logging policy, swapped atomically
+var logContextKeys atomic.Pointer[[]logContextKey]
+
+// SetLogContextKeys configures the context values LogContext adds to a log
+// record: each entry maps a log field name to the context key holding its
+// value, such as {"trace_id": traceIDKey{}}. Fields are emitted in
+// field-name order. Passing an empty map removes the configuration.
+func SetLogContextKeys(keys map[string]any) {
+	if len(keys) == 0 {
+		logContextKeys.Store(nil)
+
+		return
+	}
+
+	fields := make([]logContextKey, 0, len(keys))
+	for _, field := range slices.Sorted(maps.Keys(keys)) {
+		fields = append(fields, logContextKey{field: field, key: keys[field]})
+	}
+
+	logContextKeys.Store(&fields)
+}
+
+// appendContextLogFields appends the configured fields found on ctx to
+// logData. Keys that ctx doesn't carry are skipped.
+func appendContextLogFields(logData []any, ctx context.Context) []any {
+	fields := logContextKeys.Load()
+	if ctx == nil || fields == nil {
+		return logData
+	}
+
+	for _, field := range *fields {
+		if val := ctx.Value(field.key); val != nil {
+			logData = append(logData, field.field, val)
+		}
+	}
+
+	return logData
+}
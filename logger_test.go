@@ -0,0 +1,183 @@
+package ewrap
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+//nolint:paralleltest // mutates the package-level default logger
+func TestSetDefaultLogger(t *testing.T) {
+	defaultLog := NewMockLogger()
+
+	SetDefaultLogger(defaultLog)
+	t.Cleanup(func() { SetDefaultLogger(nil) })
+
+	t.Run("applied to New, Newf and Wrap", func(t *testing.T) {
+		for _, err := range []*Error{New(msgTest), Newf("%s", msgTest), Wrap(errPlain, msgWrapped)} {
+			if err.logger != defaultLog {
+				t.Errorf("expected default logger on %q, got %T", err.Error(), err.logger)
+			}
+		}
+
+		if got := defaultLog.GetCallCount("debug"); got != 0 {
+			t.Errorf("default logger must not log on creation, got %d debug calls", got)
+		}
+	})
+
+	t.Run("explicit WithLogger overrides default", func(t *testing.T) {
+		explicit := NewMockLogger()
+
+		err := New(msgTest, WithLogger(explicit))
+		if err.logger != explicit {
+			t.Errorf("expected explicit logger, got %T", err.logger)
+		}
+
+		if wrapped := Wrap(err, msgWrapped); wrapped.logger != explicit {
+			t.Errorf("expected inherited logger to win over the default, got %T", wrapped.logger)
+		}
+	})
+
+	t.Run("WithLogger(nil) installs no-op", func(t *testing.T) {
+		err := New(msgTest, WithLogger(nil))
+		if _, ok := err.logger.(NoopLogger); !ok {
+			t.Errorf("expected NoopLogger, got %T", err.logger)
+		}
+
+		err.Log()
+
+		if got := defaultLog.GetCallCount(severityErrorStr); got != 0 {
+			t.Errorf("no-op logger must silence the default, got %d error calls", got)
+		}
+	})
+
+	SetDefaultLogger(nil)
+
+	if err := New(msgTest); err.logger != nil {
+		t.Errorf("expected no logger after clearing the default, got %T", err.logger)
+	}
+}
+
+func TestNoopLogger(t *testing.T) {
+	t.Parallel()
+
+	var logger Logger = NoopLogger{}
+
+	logger.Error(msgTest, msgKey, msgValue)
+	logger.Debug(msgTest, msgKey, msgValue)
+	logger.Info(msgTest, msgKey, msgValue)
+}
+
+//nolint:paralleltest // mutates the package-level log severity threshold
+func TestSetLogSeverityThreshold(t *testing.T) {
+	SetLogSeverityThreshold(SeverityError)
+	t.Cleanup(func() { SetLogSeverityThreshold(SeverityInfo) })
+
+	mockLogger := NewMockLogger()
+	obs := &recordingObserver{}
+
+	New("info", WithLogger(mockLogger), WithObserver(obs), WithSeverity(SeverityInfo)).Log()
+
+	if got := mockLogger.GetCallCount(severityErrorStr); got != 0 {
+		t.Errorf("info error below the threshold was logged %d times", got)
+	}
+
+	if obs.errorCount != 1 {
+		t.Errorf("observer must still record suppressed errors, got %d", obs.errorCount)
+	}
+
+	New("critical", WithLogger(mockLogger), WithSeverity(SeverityCritical)).Log()
+	New("no context", WithLogger(mockLogger)).Log()
+
+	if got := mockLogger.GetCallCount(severityErrorStr); got != 2 {
+		t.Errorf("expected the critical and context-less errors to log, got %d records", got)
+	}
+}
+
+//nolint:paralleltest // mutates the package-level log sample rate
+func TestSetLogSampleRate(t *testing.T) {
+	t.Cleanup(func() { SetLogSampleRate(1) })
+
+	const calls = 4000
+
+	for _, rate := range []float64{0, 0.25, 1} {
+		SetLogSampleRate(rate)
+
+		mockLogger := NewMockLogger()
+		obs := &recordingObserver{}
+		err := New(msgTest, WithLogger(mockLogger), WithObserver(obs))
+
+		for range calls {
+			err.Log()
+		}
+
+		if obs.errorCount != calls {
+			t.Errorf("rate %v: observer saw %d of %d calls", rate, obs.errorCount, calls)
+		}
+
+		// A ±5 point band is over seven standard deviations at this N.
+		got := float64(mockLogger.GetCallCount(severityErrorStr)) / calls
+		if math.Abs(got-rate) > 0.05 {
+			t.Errorf("rate %v: logged fraction %.3f", rate, got)
+		}
+	}
+}
+
+type (
+	traceIDKey struct{}
+	tenantKey  struct{}
+)
+
+// logFields returns the key/value pairs of a log record as a map.
+func logFields(args []any) map[string]any {
+	fields := make(map[string]any, len(args)/2)
+	for i := 0; i+1 < len(args); i += 2 {
+		if key, ok := args[i].(string); ok {
+			fields[key] = args[i+1]
+		}
+	}
+
+	return fields
+}
+
+//nolint:paralleltest // mutates the package-level log context keys
+func TestLogContext(t *testing.T) {
+	SetLogContextKeys(map[string]any{"trace_id": traceIDKey{}, "tenant": tenantKey{}})
+	t.Cleanup(func() { SetLogContextKeys(nil) })
+
+	mockLogger := NewMockLogger()
+	err := New(msgTest, WithLogger(mockLogger))
+
+	ctx := context.WithValue(t.Context(), traceIDKey{}, "trace-1")
+	err.LogContext(ctx)
+
+	logs := mockLogger.GetLogs()
+	if got := mockLogger.GetCallCount(severityErrorStr); got != 1 {
+		t.Fatalf("expected 1 error log, got %d", got)
+	}
+
+	fields := logFields(logs[len(logs)-1].Args)
+	if fields["trace_id"] != "trace-1" {
+		t.Errorf("trace_id: got %v, want %q", fields["trace_id"], "trace-1")
+	}
+
+	if _, ok := fields["tenant"]; ok {
+		t.Error("keys missing from the context must be skipped")
+	}
+
+	if fields["error"] != msgTest {
+		t.Errorf("error: got %v, want %q", fields["error"], msgTest)
+	}
+
+	// The fields belong to the log call, not to the error.
+	if _, ok := err.GetMetadata("trace_id"); ok {
+		t.Error("context fields must not be stored as metadata")
+	}
+
+	err.Log()
+
+	logs = mockLogger.GetLogs()
+	if _, ok := logFields(logs[len(logs)-1].Args)["trace_id"]; ok {
+		t.Error("Log must not add context fields")
+	}
+}
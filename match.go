@@ -0,0 +1,48 @@
+package ewrap
+
+import (
+	"regexp"
+	"sync"
+)
+
+// matchPatterns caches the regular expressions compiled by Matches, keyed by
+// pattern. Invalid patterns are cached as a nil *regexp.Regexp so they
+// aren't recompiled on every call.
+//
+//nolint:gochecknoglobals // package-wide compile cache
+var matchPatterns sync.Map
+
+// Matches reports whether the regular expression pattern matches the full
+// Error() text, cause messages included, for classifying third-party errors
+// that carry no code or type. Each pattern is compiled once and cached, so
+// pass constant patterns rather than ones built from input. An invalid
+// pattern never matches.
+func (e *Error) Matches(pattern string) bool {
+	re := compiledPattern(pattern)
+	if re == nil {
+		return false
+	}
+
+	return re.MatchString(e.Error())
+}
+
+// compiledPattern returns the cached compilation of pattern, or nil if it
+// is invalid.
+func compiledPattern(pattern string) *regexp.Regexp {
+	if cached, ok := matchPatterns.Load(pattern); ok {
+		re, _ := cached.(*regexp.Regexp)
+
+		return re
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		matchPatterns.Store(pattern, (*regexp.Regexp)(nil))
+
+		return nil
+	}
+
+	matchPatterns.Store(pattern, re)
+
+	return re
+}
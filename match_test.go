@@ -0,0 +1,33 @@
+package ewrap
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMatches(t *testing.T) {
+	t.Parallel()
+
+	inner := fmt.Errorf("pq: %w", errRootCause)
+	err := Wrap(Wrap(inner, "querying orders"), "loading dashboard")
+
+	tests := []struct {
+		pattern string
+		want    bool
+	}{
+		{`root\s+cause$`, true},
+		{`^loading dashboard: querying orders: pq:`, true},
+		{`(?i)ROOT CAUSE`, true},
+		{`connection refused`, false},
+		{`[invalid`, false},
+	}
+
+	for _, tt := range tests {
+		// Twice, so the second call goes through the cache.
+		for range 2 {
+			if got := err.Matches(tt.pattern); got != tt.want {
+				t.Errorf("Matches(%q) = %v, want %v", tt.pattern, got, tt.want)
+			}
+		}
+	}
+}
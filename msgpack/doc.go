@@ -0,0 +1,8 @@
+// Package msgpack encodes ewrap errors and error groups as MessagePack, a
+// compact binary alternative to JSON for high-throughput error queues. It
+// is a separate module, so the MessagePack library stays out of the core
+// module's go.mod and only programs that import this package depend on it.
+//
+// The encoded shape is the one ToJSON produces: field names follow the JSON
+// tags of ewrap.ErrorOutput and ewrap.ErrorGroupSerialization.
+package msgpack
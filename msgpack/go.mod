@@ -0,0 +1,16 @@
+module github.com/hyp3rd/ewrap/msgpack
+
+go 1.26.4
+
+require (
+	github.com/hyp3rd/ewrap v0.0.0-00010101000000-000000000000
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+)
+
+require (
+	github.com/goccy/go-json v0.10.6 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/hyp3rd/ewrap => ../
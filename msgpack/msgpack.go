@@ -0,0 +1,115 @@
+package msgpack
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/hyp3rd/ewrap"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// structTag makes the codec reuse ewrap's JSON field names.
+const structTag = "json"
+
+// Marshal encodes err in the shape of (*ewrap.Error).ToJSON. Format options
+// apply as they do there.
+func Marshal(err *ewrap.Error, opts ...ewrap.FormatOption) ([]byte, error) {
+	data, encErr := encode(err.ToErrorOutput(opts...))
+	if encErr != nil {
+		return nil, fmt.Errorf("failed to marshal error to MessagePack: %w", encErr)
+	}
+
+	return data, nil
+}
+
+// MarshalGroup encodes eg in the shape of (*ewrap.ErrorGroup).ToJSON. Format
+// options apply as they do there.
+func MarshalGroup(eg *ewrap.ErrorGroup, opts ...ewrap.FormatOption) ([]byte, error) {
+	serialization := eg.ToSerialization(opts...)
+
+	data, err := encode(&serialization)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal ErrorGroup to MessagePack: %w", err)
+	}
+
+	return data, nil
+}
+
+// Unmarshal decodes data produced by Marshal. Integers in Context and
+// Metadata decode as int64 and floats as float64, whatever their original
+// Go type.
+func Unmarshal(data []byte) (*ewrap.ErrorOutput, error) {
+	var output ewrap.ErrorOutput
+
+	err := decode(data, &output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal error from MessagePack: %w", err)
+	}
+
+	return &output, nil
+}
+
+// UnmarshalGroup decodes data produced by MarshalGroup, with the same
+// numeric conventions as Unmarshal.
+func UnmarshalGroup(data []byte) (*ewrap.ErrorGroupSerialization, error) {
+	var serialization ewrap.ErrorGroupSerialization
+
+	err := decode(data, &serialization)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ErrorGroup from MessagePack: %w", err)
+	}
+
+	return &serialization, nil
+}
+
+// registerUintptr teaches the codec uintptr, which it lacks and which
+// ewrap.StackFrame.PC uses. Values travel as unsigned integers. The library
+// registry is global, so this is done once, on first use.
+//
+//nolint:gochecknoglobals // one-time codec registration
+var registerUintptr = sync.OnceFunc(func() {
+	msgpack.Register(uintptr(0),
+		func(enc *msgpack.Encoder, v reflect.Value) error {
+			return enc.EncodeUint(v.Uint())
+		},
+		func(dec *msgpack.Decoder, v reflect.Value) error {
+			n, err := dec.DecodeUint64()
+			if err != nil {
+				return err //nolint:wrapcheck // wrapped by the exported caller
+			}
+
+			v.SetUint(n)
+
+			return nil
+		},
+	)
+})
+
+func encode(v any) ([]byte, error) {
+	registerUintptr()
+
+	var buf bytes.Buffer
+
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag(structTag)
+	enc.SetOmitEmpty(true)
+
+	err := enc.Encode(v)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // wrapped by the exported caller
+	}
+
+	return buf.Bytes(), nil
+}
+
+func decode(data []byte, v any) error {
+	registerUintptr()
+
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag(structTag)
+	dec.UseLooseInterfaceDecoding(true)
+
+	return dec.Decode(v) //nolint:wrapcheck // wrapped by the exported caller
+}
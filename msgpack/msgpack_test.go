@@ -0,0 +1,93 @@
+package msgpack
+
+import (
+	"testing"
+
+	"github.com/hyp3rd/ewrap"
+)
+
+const (
+	testMessage = "payment failed"
+	testAttempt = 3
+)
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	err := ewrap.Wrap(
+		ewrap.New("card declined"),
+		testMessage,
+		ewrap.WithType(ewrap.ErrorTypeExternal),
+		ewrap.WithSeverity(ewrap.SeverityCritical),
+	).WithMetadata("provider", "stripe").WithMetadata("attempt", testAttempt)
+
+	data, encErr := Marshal(err, ewrap.WithStackTrace(false))
+	if encErr != nil {
+		t.Fatalf("Marshal: %v", encErr)
+	}
+
+	decoded, decErr := Unmarshal(data)
+	if decErr != nil {
+		t.Fatalf("Unmarshal: %v", decErr)
+	}
+
+	if decoded.Message != testMessage {
+		t.Errorf("Message: got %q, want %q", decoded.Message, testMessage)
+	}
+
+	if decoded.Type != "external" || decoded.Severity != "critical" {
+		t.Errorf("Type/Severity: got %q/%q", decoded.Type, decoded.Severity)
+	}
+
+	if decoded.Metadata["provider"] != "stripe" || decoded.Metadata["attempt"] != int64(testAttempt) {
+		t.Errorf("Metadata: got %#v", decoded.Metadata)
+	}
+
+	if decoded.Stack != "" {
+		t.Error("format options must apply")
+	}
+
+	if decoded.Cause == nil || decoded.Cause.Message != "card declined" || decoded.Depth != 2 {
+		t.Errorf("Cause: got %+v (depth %d)", decoded.Cause, decoded.Depth)
+	}
+}
+
+func TestGroupRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	eg := ewrap.ErrorGroupFromErrors(
+		ewrap.New(testMessage).WithMetadata("provider", "stripe"),
+		ewrap.New("second"),
+	)
+
+	data, encErr := MarshalGroup(eg)
+	if encErr != nil {
+		t.Fatalf("MarshalGroup: %v", encErr)
+	}
+
+	decoded, decErr := UnmarshalGroup(data)
+	if decErr != nil {
+		t.Fatalf("UnmarshalGroup: %v", decErr)
+	}
+
+	if decoded.ErrorCount != 2 || len(decoded.Errors) != 2 {
+		t.Fatalf("count: got %d/%d", decoded.ErrorCount, len(decoded.Errors))
+	}
+
+	first := decoded.Errors[0]
+	if first.Message != testMessage || first.Type != "ewrap" || first.Metadata["provider"] != "stripe" {
+		t.Errorf("first member: got %+v", first)
+	}
+
+	if len(first.StackTrace) == 0 {
+		t.Error("expected stack frames to survive the round trip")
+	}
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	t.Parallel()
+
+	if _, err := Unmarshal([]byte{0xc1}); err == nil {
+		t.Error("expected an error for invalid input")
+	}
+}
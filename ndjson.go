@@ -0,0 +1,71 @@
+package ewrap
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// ndjsonReader encodes one error per Read as the consumer drains it.
+type ndjsonReader struct {
+	errs []error
+	opts []FormatOption
+	buf  bytes.Buffer
+	next int
+}
+
+// NewNDJSONReader returns a reader streaming errs as newline-delimited JSON:
+// one compact ErrorOutput object per line, in order, shaped as ToJSON with
+// opts. Each error is encoded only when the consumer reads past the previous
+// one, so large batches are never buffered whole. Non-ewrap errors are
+// rendered with their unwrap chain; nil entries are skipped. Encoding
+// failures are returned from Read.
+func NewNDJSONReader(errs []error, opts ...FormatOption) io.Reader {
+	return &ndjsonReader{errs: errs, opts: opts}
+}
+
+// Read implements io.Reader.
+func (r *ndjsonReader) Read(p []byte) (int, error) {
+	for r.buf.Len() == 0 {
+		if r.next >= len(r.errs) {
+			return 0, io.EOF
+		}
+
+		err := r.errs[r.next]
+		r.next++
+
+		if err == nil {
+			continue
+		}
+
+		line, marshalErr := jsonMarshaler().Marshal(ndjsonOutput(err, r.opts).marshalTarget())
+		if marshalErr != nil {
+			return 0, fmt.Errorf("failed to marshal error to NDJSON: %w", marshalErr)
+		}
+
+		r.buf.Write(line)
+		r.buf.WriteByte('\n')
+	}
+
+	return r.buf.Read(p)
+}
+
+// ndjsonOutput builds the ErrorOutput for one NDJSON line.
+func ndjsonOutput(err error, opts []FormatOption) *ErrorOutput {
+	if e, ok := err.(*Error); ok {
+		return e.ToErrorOutput(opts...)
+	}
+
+	opts = withDefaultFormatOptions(opts)
+
+	output := standardErrorOutput(err, opts...)
+	for _, opt := range opts {
+		opt(output)
+	}
+
+	if !output.omitSchemaVersion {
+		output.SchemaVersion = SchemaVersion
+	}
+
+	return output
+}
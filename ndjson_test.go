@@ -0,0 +1,97 @@
+package ewrap
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+)
+
+const ndjsonBatchSize = 250
+
+func TestNDJSONReader(t *testing.T) {
+	t.Parallel()
+
+	errs := make([]error, ndjsonBatchSize)
+	for i := range errs {
+		if i%2 == 0 {
+			errs[i] = Wrap(errOriginal, msgWrapped).WithMetadata(msgKey, i)
+		} else {
+			errs[i] = fmt.Errorf("batch %d: %w", i, errPlain)
+		}
+	}
+
+	scanner := bufio.NewScanner(NewNDJSONReader(errs, WithStackTrace(false)))
+
+	lines := 0
+	for scanner.Scan() {
+		var output ErrorOutput
+		if err := json.Unmarshal(scanner.Bytes(), &output); err != nil {
+			t.Fatalf("line %d is not a JSON object: %v\n%s", lines, err, scanner.Text())
+		}
+
+		want := errs[lines].Error()
+		if lines%2 == 0 {
+			want = msgWrapped
+		}
+
+		if output.Message != want {
+			t.Errorf("line %d: expected message %q, got %q", lines, want, output.Message)
+		}
+
+		if output.SchemaVersion != SchemaVersion || output.Stack != "" {
+			t.Errorf("line %d: format options not applied: %+v", lines, output)
+		}
+
+		lines++
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf(unexpectedErrFn, err)
+	}
+
+	if lines != len(errs) {
+		t.Errorf("expected %d lines, got %d", len(errs), lines)
+	}
+}
+
+func TestNDJSONReaderSkipsNil(t *testing.T) {
+	t.Parallel()
+
+	data, err := io.ReadAll(NewNDJSONReader([]error{nil, errFirst, nil}))
+	if err != nil {
+		t.Fatalf(unexpectedErrFn, err)
+	}
+
+	if got := bytes.Count(data, []byte("\n")); got != 1 {
+		t.Errorf("expected nil entries to be skipped, got %d lines", got)
+	}
+
+	empty, err := io.ReadAll(NewNDJSONReader(nil))
+	if err != nil || len(empty) != 0 {
+		t.Errorf("expected an empty stream, got %q, %v", empty, err)
+	}
+}
+
+//nolint:paralleltest // mutates the package-level JSON marshaler
+func TestNDJSONReaderIsLazy(t *testing.T) {
+	counting := &countingMarshaler{}
+
+	SetJSONMarshaler(counting)
+	t.Cleanup(func() { SetJSONMarshaler(nil) })
+
+	reader := NewNDJSONReader([]error{New(msgFirst), New(msgSecond), New(msgTest)})
+	if got := counting.calls.Load(); got != 0 {
+		t.Fatalf("nothing should be encoded before the first Read, got %d", got)
+	}
+
+	if _, err := reader.Read(make([]byte, 1)); err != nil {
+		t.Fatalf(unexpectedErrFn, err)
+	}
+
+	if got := counting.calls.Load(); got != 1 {
+		t.Errorf("expected one error encoded after a short read, got %d", got)
+	}
+}
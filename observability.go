@@ -1,5 +1,15 @@
 package ewrap
 
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// defaultObserverBuffer is the queue capacity NewBufferedObserver uses when
+// given a non-positive one.
+const defaultObserverBuffer = 1024
+
 // Observer receives notifications about errors. Implementations must be
 // goroutine-safe; calls happen synchronously from the goroutine that invoked
 // (*Error).Log.
@@ -10,3 +20,143 @@ type Observer interface {
 	// RecordError is called when an error is logged.
 	RecordError(message string)
 }
+
+// WarningObserver is an optional extension of Observer for notices that are
+// not errors in their own right, such as WithCode receiving an empty code.
+// Observers that don't implement it simply don't get the warnings.
+type WarningObserver interface {
+	// RecordWarning is called with a description of the problem.
+	RecordWarning(message string)
+}
+
+// observerContextKey is the context key ContextWithObserver stores under.
+type observerContextKey struct{}
+
+// ContextWithObserver returns a copy of ctx carrying obs, for request-scoped
+// observers such as one bound to the active tracing span. Retrieve it with
+// WithObserverFromContext.
+func ContextWithObserver(ctx context.Context, obs Observer) context.Context {
+	return context.WithValue(ctx, observerContextKey{}, obs)
+}
+
+// WithObserverFromContext attaches the Observer stored on ctx by
+// ContextWithObserver. It is a no-op when ctx is nil or carries no observer,
+// leaving any inherited or previously set observer in place.
+func WithObserverFromContext(ctx context.Context) Option {
+	return func(err *Error) {
+		if ctx == nil {
+			return
+		}
+
+		if obs, ok := ctx.Value(observerContextKey{}).(Observer); ok && obs != nil {
+			err.observer = obs
+		}
+	}
+}
+
+// observerEvent is one queued BufferedObserver notification.
+type observerEvent struct {
+	message string
+	warning bool
+}
+
+// BufferedObserver decorates an Observer so that Log never waits on it:
+// events go onto a bounded queue drained by a background goroutine, and
+// events that arrive while the queue is full are dropped and counted rather
+// than blocking the caller. Warnings are forwarded when the wrapped observer
+// implements WarningObserver. Call Close to flush the queue and stop the
+// goroutine.
+type BufferedObserver struct {
+	next    Observer
+	events  chan observerEvent
+	done    chan struct{}
+	dropped atomic.Uint64
+
+	// mu guards closed against sends racing with Close; senders hold the
+	// read lock, so they never block one another.
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewBufferedObserver starts a BufferedObserver that forwards to next
+// through a queue of capacity events (1024 when capacity is not positive).
+func NewBufferedObserver(next Observer, capacity int) *BufferedObserver {
+	if capacity <= 0 {
+		capacity = defaultObserverBuffer
+	}
+
+	b := &BufferedObserver{
+		next:   next,
+		events: make(chan observerEvent, capacity),
+		done:   make(chan struct{}),
+	}
+
+	go b.run()
+
+	return b
+}
+
+// RecordError queues message for the wrapped observer, dropping it if the
+// queue is full or the observer is closed.
+func (b *BufferedObserver) RecordError(message string) {
+	b.enqueue(observerEvent{message: message})
+}
+
+// RecordWarning queues message for the wrapped observer's RecordWarning,
+// dropping it if the queue is full or the observer is closed.
+func (b *BufferedObserver) RecordWarning(message string) {
+	b.enqueue(observerEvent{message: message, warning: true})
+}
+
+// Dropped returns how many events were discarded because the queue was full
+// or the observer was closed.
+func (b *BufferedObserver) Dropped() uint64 {
+	return b.dropped.Load()
+}
+
+// Close stops accepting events and blocks until every queued event has been
+// delivered. It is safe to call more than once.
+func (b *BufferedObserver) Close() {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		close(b.events)
+	}
+	b.mu.Unlock()
+
+	<-b.done
+}
+
+func (b *BufferedObserver) enqueue(event observerEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		b.dropped.Add(1)
+
+		return
+	}
+
+	select {
+	case b.events <- event:
+	default:
+		b.dropped.Add(1)
+	}
+}
+
+// run delivers queued events until the queue is closed and drained.
+func (b *BufferedObserver) run() {
+	defer close(b.done)
+
+	warner, _ := b.next.(WarningObserver)
+
+	for event := range b.events {
+		switch {
+		case b.next == nil:
+		case !event.warning:
+			b.next.RecordError(event.message)
+		case warner != nil:
+			warner.RecordWarning(event.message)
+		}
+	}
+}
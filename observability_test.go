@@ -1,6 +1,10 @@
 package ewrap
 
-import "testing"
+import (
+	"context"
+	"sync"
+	"testing"
+)
 
 // recordingObserver implements Observer for tests.
 type recordingObserver struct {
@@ -46,3 +50,114 @@ func TestObserverIsOptional(t *testing.T) {
 	err := New(msgTestError)
 	err.Log() // Should not panic without an observer
 }
+
+func TestWithObserverFromContext(t *testing.T) {
+	t.Parallel()
+
+	obs := &recordingObserver{}
+	ctx := ContextWithObserver(context.Background(), obs)
+
+	err := New(msgTestError, WithObserverFromContext(ctx))
+	err.Log()
+
+	if obs.errorCount != 1 {
+		t.Fatalf("expected 1 error recorded, got %d", obs.errorCount)
+	}
+}
+
+func TestWithObserverFromContextAbsent(t *testing.T) {
+	t.Parallel()
+
+	explicit := &recordingObserver{}
+
+	err := New(msgTestError, WithObserver(explicit), WithObserverFromContext(context.Background()))
+	if err.observer != explicit {
+		t.Fatal("an observer-less context must leave the existing observer in place")
+	}
+
+	//nolint:staticcheck // a nil context is exactly what is under test
+	err = New(msgTestError, WithObserverFromContext(nil))
+	if err.observer != nil {
+		t.Fatalf("expected no observer, got %v", err.observer)
+	}
+}
+
+// blockingObserver holds up its first RecordError until release is closed,
+// signalling on started once it is blocked.
+type blockingObserver struct {
+	warningObserver
+
+	started chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (b *blockingObserver) RecordError(message string) {
+	b.once.Do(func() {
+		close(b.started)
+		<-b.release
+	})
+
+	b.warningObserver.RecordError(message)
+}
+
+func TestBufferedObserverDelivers(t *testing.T) {
+	t.Parallel()
+
+	next := &warningObserver{}
+	buffered := NewBufferedObserver(next, defaultMaxAttempts*2)
+
+	for range defaultMaxAttempts {
+		New(msgTest, WithObserver(buffered)).Log()
+	}
+
+	New(msgTest, WithObserver(buffered), WithCode(""))
+	buffered.Close()
+	buffered.Close() // idempotent
+
+	if next.errorCount != defaultMaxAttempts {
+		t.Errorf("delivered errors: got %d, want %d", next.errorCount, defaultMaxAttempts)
+	}
+
+	if len(next.warnings) != 1 {
+		t.Errorf("delivered warnings: got %d, want 1", len(next.warnings))
+	}
+
+	if got := buffered.Dropped(); got != 0 {
+		t.Errorf("dropped: got %d, want 0", got)
+	}
+}
+
+func TestBufferedObserverDropsOnOverflow(t *testing.T) {
+	t.Parallel()
+
+	const capacity, overflow = 2, 3
+
+	next := &blockingObserver{started: make(chan struct{}), release: make(chan struct{})}
+	buffered := NewBufferedObserver(next, capacity)
+
+	// The first event occupies the worker, the next ones fill the queue.
+	buffered.RecordError(msgFirst)
+	<-next.started
+
+	for range capacity + overflow {
+		buffered.RecordError(msgSecond)
+	}
+
+	if got := buffered.Dropped(); got != overflow {
+		t.Errorf("dropped: got %d, want %d", got, overflow)
+	}
+
+	close(next.release)
+	buffered.Close()
+
+	if want := 1 + capacity; next.errorCount != want {
+		t.Errorf("delivered: got %d, want %d", next.errorCount, want)
+	}
+
+	buffered.RecordError(msgTest)
+
+	if got := buffered.Dropped(); got != overflow+1 {
+		t.Errorf("expected events after Close to be dropped, got %d", got)
+	}
+}
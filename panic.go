@@ -0,0 +1,96 @@
+package ewrap
+
+import "fmt"
+
+// RecoverError converts a value returned by recover() into an *Error typed
+// ErrorTypeInternal with SeverityCritical. When the value is an error its
+// message is kept verbatim and it becomes the cause, so errors.Is and
+// errors.As still match it; any other value is rendered with fmt.Sprint.
+// The stack is captured where RecoverError is called, which inside a
+// deferred function still includes the frames that panicked. Returns nil
+// when recovered is nil.
+//
+//	defer func() {
+//		if err := ewrap.RecoverError(recover()); err != nil {
+//			err.Log()
+//		}
+//	}()
+func RecoverError(recovered any) *Error {
+	return recoverErrorAt(callerSkipNew+1, recovered)
+}
+
+// Recover is meant to be deferred directly. It recovers a panic, converts
+// it with RecoverError, and stores the result in *errPtr, overwriting any
+// error already there. It does nothing when no panic is in flight.
+//
+//	func work() (err error) {
+//		defer ewrap.Recover(&err)
+//		...
+//	}
+func Recover(errPtr *error) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	err := recoverErrorAt(callerSkipNew+1, recovered)
+	if errPtr != nil {
+		*errPtr = err
+	}
+}
+
+// recoverErrorAt builds the *Error for RecoverError and Recover; skip is
+// relative to recoverErrorAt's caller, as for newAt.
+func recoverErrorAt(skip int, recovered any) *Error {
+	if recovered == nil {
+		return nil
+	}
+
+	opts := []Option{WithType(ErrorTypeInternal), WithSeverity(SeverityCritical)}
+
+	cause, ok := recovered.(error)
+	if !ok {
+		return newAt(skip+1, fmt.Sprint(recovered), opts...)
+	}
+
+	err := newAt(skip+1, cause.Error(), opts...)
+	err.cause = cause
+	err.fullMsg = true
+
+	return err
+}
+
+// Must returns v when err is nil and panics otherwise, for initialization
+// code and tests where an error is unrecoverable. The panic value is an
+// *Error typed ErrorTypeInternal that keeps err's message verbatim and has it
+// as its cause, so recovery code (see RecoverError) can inspect it with
+// errors.Is and errors.As.
+//
+//	tmpl := ewrap.Must(template.ParseFiles("index.html"))
+func Must[T any](v T, err error) T {
+	if err != nil {
+		panic(mustErrorAt(callerSkipNew+1, err))
+	}
+
+	return v
+}
+
+// MustNot panics like Must when err is non-nil; use it for calls that return
+// only an error.
+//
+//	ewrap.MustNot(db.Ping())
+func MustNot(err error) {
+	if err != nil {
+		panic(mustErrorAt(callerSkipNew+1, err))
+	}
+}
+
+// mustErrorAt builds the panic value for Must and MustNot; skip is relative
+// to mustErrorAt's caller, as for newAt.
+func mustErrorAt(skip int, cause error) *Error {
+	err := newAt(skip+1, cause.Error(), WithType(ErrorTypeInternal))
+	err.cause = cause
+	err.fullMsg = true
+
+	return err
+}
@@ -0,0 +1,157 @@
+package ewrap
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// panicky panics with value so tests can recover it from a real panic.
+//
+//go:noinline
+func panicky(value any) {
+	panic(value)
+}
+
+func recoverFrom(value any) (recovered *Error) {
+	defer func() {
+		recovered = RecoverError(recover())
+	}()
+
+	panicky(value)
+
+	return nil
+}
+
+func TestRecoverError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("non-error value", func(t *testing.T) {
+		t.Parallel()
+
+		err := recoverFrom(formatNumber)
+		if err == nil {
+			t.Fatal("expected an error from a recovered panic")
+		}
+
+		if got := err.Error(); got != "42" {
+			t.Errorf("message: got %q, want %q", got, "42")
+		}
+
+		if !err.IsType(ErrorTypeInternal) || err.SeverityLevel() != SeverityCritical {
+			t.Errorf("expected internal/critical, got %v/%v",
+				err.GetErrorContext().Type, err.GetErrorContext().Severity)
+		}
+
+		if !strings.Contains(err.Stack(), "panicky") {
+			t.Errorf("expected stack to include the panicking frame, got:\n%s", err.Stack())
+		}
+	})
+
+	t.Run("error value", func(t *testing.T) {
+		t.Parallel()
+
+		err := recoverFrom(errSentinel)
+
+		if got := err.Error(); got != msgSentinel {
+			t.Errorf("message: got %q, want %q", got, msgSentinel)
+		}
+
+		if !errors.Is(err, errSentinel) {
+			t.Error("expected recovered error to remain in the chain")
+		}
+	})
+
+	t.Run("no panic", func(t *testing.T) {
+		t.Parallel()
+
+		if RecoverError(nil) != nil {
+			t.Error("expected nil when nothing was recovered")
+		}
+	})
+}
+
+func TestRecover(t *testing.T) {
+	t.Parallel()
+
+	run := func(value any) (err error) {
+		defer Recover(&err)
+
+		if value != nil {
+			panicky(value)
+		}
+
+		return nil
+	}
+
+	err := run(msgBoom)
+	if err == nil || err.Error() != msgBoom {
+		t.Fatalf("expected recovered %q, got %v", msgBoom, err)
+	}
+
+	var ewrapErr *Error
+	if !errors.As(err, &ewrapErr) || !ewrapErr.IsType(ErrorTypeInternal) {
+		t.Errorf("expected an internal *Error, got %T", err)
+	}
+
+	if err := run(nil); err != nil {
+		t.Errorf("expected nil without a panic, got %v", err)
+	}
+}
+
+// mustPanic runs fn and returns what it panicked with, or nil.
+func mustPanic(fn func()) (recovered any) {
+	defer func() {
+		recovered = recover()
+	}()
+
+	fn()
+
+	return nil
+}
+
+func TestMust(t *testing.T) {
+	t.Parallel()
+
+	t.Run("happy path", func(t *testing.T) {
+		t.Parallel()
+
+		if got := Must(msgValue, nil); got != msgValue {
+			t.Errorf("Must: got %q, want %q", got, msgValue)
+		}
+
+		if recovered := mustPanic(func() { MustNot(nil) }); recovered != nil {
+			t.Errorf("MustNot(nil) panicked with %v", recovered)
+		}
+	})
+
+	t.Run("panics with an *Error", func(t *testing.T) {
+		t.Parallel()
+
+		checks := map[string]func(){
+			"Must":    func() { _ = Must(0, errOriginal) },
+			"MustNot": func() { MustNot(errOriginal) },
+		}
+
+		for name, fn := range checks {
+			recovered := mustPanic(fn)
+
+			err, ok := recovered.(*Error)
+			if !ok {
+				t.Fatalf("%s: panic value %T is not *Error", name, recovered)
+			}
+
+			if err.Error() != msgOriginal {
+				t.Errorf("%s: message %q, want %q", name, err.Error(), msgOriginal)
+			}
+
+			if !errors.Is(err, errOriginal) {
+				t.Errorf("%s: errors.Is must find the original error", name)
+			}
+
+			if !err.IsType(ErrorTypeInternal) {
+				t.Errorf("%s: expected ErrorTypeInternal", name)
+			}
+		}
+	})
+}
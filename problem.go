@@ -0,0 +1,116 @@
+package ewrap
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// problemTypeBlank is the RFC 9457 problem type for problems with no more
+// specific type URI.
+const problemTypeBlank = "about:blank"
+
+// ProblemDetails is an RFC 9457 (application/problem+json) document. The
+// top level of an ErrorGroup's document lists one member per error in
+// Errors.
+type ProblemDetails struct {
+	// Type is a URI identifying the problem type; always about:blank here.
+	Type string `json:"type,omitempty"`
+	// Title is the HTTP status text for Status.
+	Title string `json:"title"`
+	// Status is the HTTP status code.
+	Status int `json:"status"`
+	// Detail explains this occurrence of the problem.
+	Detail string `json:"detail,omitempty"`
+	// Code is the error's code, if it has one.
+	Code Code `json:"code,omitempty"`
+	// Errors holds the per-error members of a group document.
+	Errors []ProblemDetails `json:"errors,omitempty"`
+}
+
+// typeStatus maps an ErrorType to the HTTP status a problem document reports
+// when no status was attached with WithHTTPStatus.
+func typeStatus(t ErrorType) int {
+	switch t {
+	case ErrorTypeValidation:
+		return http.StatusUnprocessableEntity
+	case ErrorTypeNotFound:
+		return http.StatusNotFound
+	case ErrorTypePermission:
+		return http.StatusForbidden
+	case ErrorTypeNetwork:
+		return http.StatusServiceUnavailable
+	case ErrorTypeExternal:
+		return http.StatusBadGateway
+	case ErrorTypeUnknown, ErrorTypeDatabase, ErrorTypeConfiguration, ErrorTypeInternal:
+		fallthrough
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// problemMember builds the problem document for a single error. The status
+// is the one attached with WithHTTPStatus, or else derived from the error's
+// type. The detail is the redacted SafeError text when the chain holds an
+// *Error, since problem documents are usually sent to clients.
+func problemMember(err error) ProblemDetails {
+	status := HTTPStatus(err)
+	if status == 0 {
+		status = typeStatus(typeOf(err))
+	}
+
+	member := ProblemDetails{
+		Type:   problemTypeBlank,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: err.Error(),
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		member.Detail = e.SafeError()
+		member.Code = e.Code()
+	}
+
+	return member
+}
+
+// ToProblemJSON renders the group as one RFC 9457 problem document with an
+// errors array holding a problem member per error, as batch endpoints return
+// for many validation failures. The top-level status is that of the
+// highest-severity member (the first one on ties), and each member's status
+// is its WithHTTPStatus value or, failing that, derived from its type, so a
+// group of validation errors reports 422. An empty group reports 500.
+func (eg *ErrorGroup) ToProblemJSON() (string, error) {
+	eg.mu.RLock()
+
+	problem := ProblemDetails{
+		Type:   problemTypeBlank,
+		Status: http.StatusInternalServerError,
+		Detail: fmt.Sprintf("%d errors occurred", len(eg.errors)),
+		Errors: make([]ProblemDetails, 0, len(eg.errors)),
+	}
+
+	highest := SeverityInfo
+
+	for i, err := range eg.errors {
+		member := problemMember(err)
+		problem.Errors = append(problem.Errors, member)
+
+		if severity := severityOf(err); i == 0 || severity > highest {
+			highest = severity
+			problem.Status = member.Status
+		}
+	}
+
+	eg.mu.RUnlock()
+
+	problem.Title = http.StatusText(problem.Status)
+
+	data, err := jsonMarshaler().MarshalIndent(problem, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal ErrorGroup to problem JSON: %w", err)
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,73 @@
+package ewrap
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+func TestErrorGroupToProblemJSON(t *testing.T) {
+	t.Parallel()
+
+	group := NewErrorGroup()
+	group.Add(New("email is required", WithContext(t.Context(), ErrorTypeValidation, SeverityWarning)))
+	group.Add(New("age must be positive", WithContext(t.Context(), ErrorTypeValidation, SeverityError),
+		WithCode(codeConflict), WithSafeMessage("age is invalid")))
+	group.Add(New("name too long", WithContext(t.Context(), ErrorTypeValidation, SeverityWarning)))
+
+	out, err := group.ToProblemJSON()
+	if err != nil {
+		t.Fatalf(unexpectedErrFn, err)
+	}
+
+	var problem ProblemDetails
+	if err := json.Unmarshal([]byte(out), &problem); err != nil {
+		t.Fatalf("failed to decode problem JSON: %v", err)
+	}
+
+	if problem.Status != http.StatusUnprocessableEntity {
+		t.Errorf("status: got %d, want %d", problem.Status, http.StatusUnprocessableEntity)
+	}
+
+	if problem.Title != http.StatusText(http.StatusUnprocessableEntity) {
+		t.Errorf("title: got %q", problem.Title)
+	}
+
+	if len(problem.Errors) != 3 {
+		t.Fatalf("errors: got %d members, want 3", len(problem.Errors))
+	}
+
+	member := problem.Errors[1]
+	if member.Detail != "age is invalid" || member.Code != codeConflict || member.Status != http.StatusUnprocessableEntity {
+		t.Errorf("unexpected member: %+v", member)
+	}
+}
+
+func TestErrorGroupToProblemJSONStatusFromHighestSeverity(t *testing.T) {
+	t.Parallel()
+
+	group := NewErrorGroup()
+	group.Add(New(msgFirst, WithContext(t.Context(), ErrorTypeValidation, SeverityWarning)))
+	group.Add(New(msgSecond, WithContext(t.Context(), ErrorTypeDatabase, SeverityCritical),
+		WithHTTPStatus(http.StatusServiceUnavailable)))
+	group.Add(errPlain)
+
+	out, err := group.ToProblemJSON()
+	if err != nil {
+		t.Fatalf(unexpectedErrFn, err)
+	}
+
+	var problem ProblemDetails
+	if err := json.Unmarshal([]byte(out), &problem); err != nil {
+		t.Fatalf("failed to decode problem JSON: %v", err)
+	}
+
+	if problem.Status != http.StatusServiceUnavailable {
+		t.Errorf("status: got %d, want %d", problem.Status, http.StatusServiceUnavailable)
+	}
+
+	if got := problem.Errors[2]; got.Status != http.StatusInternalServerError || got.Detail != errPlain.Error() {
+		t.Errorf("unexpected plain member: %+v", got)
+	}
+}
@@ -0,0 +1,111 @@
+package ewrap
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// recoveryPlaceholder matches a {key} placeholder in a recovery suggestion.
+//
+//nolint:gochecknoglobals // compiled once, immutable
+var recoveryPlaceholder = regexp.MustCompile(`\{([^{}\s]+)\}`)
+
+// recoveryRegistry holds package-wide recovery guidance keyed by ErrorType.
+// It is consulted when an error carries no explicit RecoverySuggestion.
+//
+//nolint:gochecknoglobals // package-wide registry guarded by its own lock
+var recoveryRegistry = struct {
+	mu          sync.RWMutex
+	suggestions map[ErrorType]*RecoverySuggestion
+}{
+	suggestions: make(map[ErrorType]*RecoverySuggestion),
+}
+
+// RegisterRecovery installs rs as the default recovery suggestion for errors
+// of type t. Serialized output falls back to it when the error was not given
+// one via WithRecoverySuggestion. Passing nil removes the registration.
+//
+// The registry is goroutine-safe but global; register suggestions during
+// program initialization.
+func RegisterRecovery(t ErrorType, rs *RecoverySuggestion) {
+	recoveryRegistry.mu.Lock()
+	defer recoveryRegistry.mu.Unlock()
+
+	if rs == nil {
+		delete(recoveryRegistry.suggestions, t)
+
+		return
+	}
+
+	recoveryRegistry.suggestions[t] = rs
+}
+
+// RecoveryFor returns the recovery suggestion registered for t, or nil.
+func RecoveryFor(t ErrorType) *RecoverySuggestion {
+	recoveryRegistry.mu.RLock()
+	defer recoveryRegistry.mu.RUnlock()
+
+	return recoveryRegistry.suggestions[t]
+}
+
+// effectiveRecovery returns the explicit recovery suggestion when set, and
+// otherwise the one registered for the error's type.
+func (e *Error) effectiveRecovery() *RecoverySuggestion {
+	if e.recovery != nil {
+		return e.recovery
+	}
+
+	if e.errorContext == nil {
+		return nil
+	}
+
+	return RecoveryFor(e.errorContext.Type)
+}
+
+// interpolate returns a copy of rs with {key} placeholders in Message and
+// Actions replaced by the matching metadata values. Placeholders without a
+// matching key are kept verbatim when keepMissing is true and removed
+// otherwise. rs itself, which may be shared through the registry, is never
+// modified; it is returned as-is when it has no placeholders.
+func (rs *RecoverySuggestion) interpolate(metadata map[string]any, keepMissing bool) *RecoverySuggestion {
+	if rs == nil || !rs.hasPlaceholders() {
+		return rs
+	}
+
+	replace := func(text string) string {
+		return recoveryPlaceholder.ReplaceAllStringFunc(text, func(placeholder string) string {
+			if val, ok := metadata[placeholder[1:len(placeholder)-1]]; ok {
+				return fmt.Sprint(val)
+			}
+
+			if keepMissing {
+				return placeholder
+			}
+
+			return ""
+		})
+	}
+
+	out := *rs
+	out.Message = replace(rs.Message)
+
+	if rs.Actions != nil {
+		out.Actions = make([]string, len(rs.Actions))
+		for i, action := range rs.Actions {
+			out.Actions[i] = replace(action)
+		}
+	}
+
+	return &out
+}
+
+// hasPlaceholders reports whether Message or any action may contain a
+// placeholder.
+func (rs *RecoverySuggestion) hasPlaceholders() bool {
+	hasBrace := func(text string) bool { return strings.ContainsRune(text, '{') }
+
+	return hasBrace(rs.Message) || slices.ContainsFunc(rs.Actions, hasBrace)
+}
@@ -0,0 +1,108 @@
+package ewrap
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+//nolint:paralleltest // mutates global registry
+func TestRecoveryRegistryFallback(t *testing.T) {
+	registered := &RecoverySuggestion{Message: "check database connectivity"}
+
+	RegisterRecovery(ErrorTypeDatabase, registered)
+	t.Cleanup(func() { RegisterRecovery(ErrorTypeDatabase, nil) })
+
+	if got := RecoveryFor(ErrorTypeDatabase); got != registered {
+		t.Fatalf("RecoveryFor: got %v, want %v", got, registered)
+	}
+
+	err := New(msgTestError, WithContext(context.Background(), ErrorTypeDatabase, SeverityError))
+
+	output := err.toErrorOutput()
+	if output.Recovery != registered {
+		t.Errorf("expected registry fallback, got %v", output.Recovery)
+	}
+
+	if err.Recovery() != nil {
+		t.Error("Recovery() must only report explicitly attached suggestions")
+	}
+}
+
+//nolint:paralleltest // mutates global registry
+func TestRecoveryRegistryExplicitOverrides(t *testing.T) {
+	RegisterRecovery(ErrorTypeNetwork, &RecoverySuggestion{Message: "registered"})
+	t.Cleanup(func() { RegisterRecovery(ErrorTypeNetwork, nil) })
+
+	explicit := &RecoverySuggestion{Message: "explicit"}
+	err := New(
+		msgTestError,
+		WithContext(context.Background(), ErrorTypeNetwork, SeverityError),
+		WithRecoverySuggestion(explicit),
+	)
+
+	output := err.toErrorOutput()
+	if output.Recovery != explicit {
+		t.Errorf("expected explicit suggestion to win, got %v", output.Recovery)
+	}
+}
+
+func TestRecoveryRegistryUnregistered(t *testing.T) {
+	t.Parallel()
+
+	if RecoveryFor(ErrorTypeConfiguration) != nil {
+		t.Error("expected nil for a type with no registration")
+	}
+
+	if output := New(msgTestError).toErrorOutput(); output.Recovery != nil {
+		t.Errorf("expected no recovery without context, got %v", output.Recovery)
+	}
+}
+
+func TestRecoveryTemplating(t *testing.T) {
+	t.Parallel()
+
+	suggestion := &RecoverySuggestion{
+		Message: "check connectivity to {host}",
+		Actions: []string{"ping {host}", "retry after {retry_after}s", "page {oncall}"},
+	}
+
+	err := New(msgTestError, WithRecoverySuggestion(suggestion)).
+		WithMetadata("host", "db-1.internal").
+		WithMetadata("retry_after", 30)
+
+	jsonStr, jsonErr := err.ToJSON()
+	if jsonErr != nil {
+		t.Fatalf(unexpectedErrFn, jsonErr)
+	}
+
+	var output ErrorOutput
+	if unmarshalErr := json.Unmarshal([]byte(jsonStr), &output); unmarshalErr != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", unmarshalErr)
+	}
+
+	if output.Recovery == nil || output.Recovery.Message != "check connectivity to db-1.internal" {
+		t.Fatalf("expected the host interpolated into the message, got %+v", output.Recovery)
+	}
+
+	want := []string{"ping db-1.internal", "retry after 30s", "page {oncall}"}
+	if !slices.Equal(output.Recovery.Actions, want) {
+		t.Errorf("expected actions %q, got %q", want, output.Recovery.Actions)
+	}
+
+	if suggestion.Message != "check connectivity to {host}" {
+		t.Error("interpolation must not modify the attached suggestion")
+	}
+
+	dropped := err.ToErrorOutput(WithMissingPlaceholdersEmpty())
+	if got := dropped.Recovery.Actions[2]; got != "page " {
+		t.Errorf("expected a missing key to render empty, got %q", got)
+	}
+
+	static := &RecoverySuggestion{Message: "no placeholders"}
+	if got := New(msgTestError, WithRecoverySuggestion(static)).toErrorOutput().Recovery; got != static {
+		t.Error("suggestions without placeholders should be returned as-is")
+	}
+}
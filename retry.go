@@ -1,7 +1,9 @@
 package ewrap
 
 import (
+	"context"
 	"errors"
+	"math/rand/v2"
 	"time"
 )
 
@@ -17,6 +19,19 @@ type RetryInfo struct {
 	LastAttempt time.Time
 	// ShouldRetry is a function that determines if a retry should be attempted.
 	ShouldRetry func(error) bool
+	// MaxElapsed is the total time budget for retrying, measured from when
+	// the retry info was attached. Zero means no budget.
+	MaxElapsed time.Duration
+	// Jitter randomizes NextDelay by up to ±Jitter×Delay; it is clamped to
+	// [0, 1]. Zero disables jitter.
+	Jitter float64
+	// Deadline is when the caller stops waiting, typically a context
+	// deadline. CanRetry reports false once the longest delay NextDelay can
+	// return would pass it. Zero means no deadline.
+	Deadline time.Time
+
+	// firstAttempt anchors the MaxElapsed budget.
+	firstAttempt time.Time
 }
 
 // RetryOption configures RetryInfo.
@@ -25,11 +40,13 @@ type RetryOption func(*RetryInfo)
 // WithRetry adds retry information to the error.
 func WithRetry(maxAttempts int, delay time.Duration, opts ...RetryOption) Option {
 	return func(err *Error) {
+		now := time.Now()
 		retryInfo := &RetryInfo{
-			MaxAttempts: maxAttempts,
-			Delay:       delay,
-			LastAttempt: time.Now(),
-			ShouldRetry: defaultShouldRetry,
+			MaxAttempts:  maxAttempts,
+			Delay:        delay,
+			LastAttempt:  now,
+			ShouldRetry:  defaultShouldRetry,
+			firstAttempt: now,
 		}
 
 		for _, opt := range opts {
@@ -49,18 +66,81 @@ func WithRetryShould(fn func(error) bool) RetryOption {
 	}
 }
 
-// defaultShouldRetry is the default retry decision function.
-// Validation errors are not retried by default.
+// WithMaxElapsed caps the total time spent retrying: once d has elapsed
+// since the retry info was attached, CanRetry reports false even if attempts
+// remain.
+func WithMaxElapsed(d time.Duration) RetryOption {
+	return func(ri *RetryInfo) {
+		ri.MaxElapsed = d
+	}
+}
+
+// WithContextTimeoutFromDeadline bounds retrying by ctx's deadline, if it has
+// one: CanRetry reports false as soon as the remaining time is shorter than
+// the longest delay NextDelay can return (Delay, stretched by Jitter), so the
+// caller returns the last error instead of sleeping past the point where
+// nobody is waiting for the result. ewrap has no retry executor; the bound
+// applies to a caller's own CanRetry / NextDelay loop.
+func WithContextTimeoutFromDeadline(ctx context.Context) RetryOption {
+	return func(ri *RetryInfo) {
+		if ctx == nil {
+			return
+		}
+
+		if deadline, ok := ctx.Deadline(); ok {
+			ri.Deadline = deadline
+		}
+	}
+}
+
+// WithJitter randomizes NextDelay by up to ±fraction of Delay, spreading out
+// retries from many clients that failed together. fraction is clamped to
+// [0, 1].
+func WithJitter(fraction float64) RetryOption {
+	return func(ri *RetryInfo) {
+		ri.Jitter = min(max(fraction, 0), 1)
+	}
+}
+
+// NextDelay returns the delay to wait before the next attempt: Delay,
+// perturbed by Jitter when set.
+func (ri *RetryInfo) NextDelay() time.Duration {
+	jitter := min(max(ri.Jitter, 0), 1)
+	if jitter == 0 || ri.Delay <= 0 {
+		return ri.Delay
+	}
+
+	// A uniform factor in [1-jitter, 1+jitter); retry spreading needs no
+	// cryptographic randomness.
+	factor := 1 + jitter*(2*rand.Float64()-1) //nolint:gosec // non-cryptographic jitter
+
+	return time.Duration(float64(ri.Delay) * factor)
+}
+
+// maxDelay returns the longest delay NextDelay can return.
+func (ri *RetryInfo) maxDelay() time.Duration {
+	jitter := min(max(ri.Jitter, 0), 1)
+
+	return time.Duration(float64(ri.Delay) * (1 + jitter))
+}
+
+// defaultShouldRetry is the default retry decision function. The first
+// layer in the chain with an explicit signal decides: a WithRetryable flag,
+// the Retryable interface, or a validation type or cancelled or expired
+// context, which are not retried. Anything else is retried.
 func defaultShouldRetry(err error) bool {
-	var wrappedErr *Error
-	if errors.As(err, &wrappedErr) && wrappedErr.errorContext != nil {
-		return wrappedErr.errorContext.Type != ErrorTypeValidation
+	for cur := err; cur != nil; cur = errors.Unwrap(cur) {
+		if retryable, ok := retryDecision(cur); ok {
+			return retryable
+		}
 	}
 
 	return true
 }
 
-// CanRetry checks if the error can be retried.
+// CanRetry checks if the error can be retried: attempts remain, the
+// MaxElapsed budget (if any) is not exhausted, the longest jittered delay
+// ends before Deadline (if any), and ShouldRetry agrees.
 func (e *Error) CanRetry() bool {
 	e.mu.RLock()
 	retryInfo := e.retry
@@ -70,6 +150,14 @@ func (e *Error) CanRetry() bool {
 		return false
 	}
 
+	if retryInfo.MaxElapsed > 0 && time.Since(retryInfo.firstAttempt) > retryInfo.MaxElapsed {
+		return false
+	}
+
+	if !retryInfo.Deadline.IsZero() && time.Until(retryInfo.Deadline) < retryInfo.maxDelay() {
+		return false
+	}
+
 	return retryInfo.CurrentAttempt < retryInfo.MaxAttempts &&
 		retryInfo.ShouldRetry(e)
 }
@@ -86,3 +174,34 @@ func (e *Error) IncrementRetry() {
 	e.retry.CurrentAttempt++
 	e.retry.LastAttempt = time.Now()
 }
+
+// RetryInfo returns a snapshot of the error's retry information and whether
+// any is attached. The copy is taken under the error's lock, so it is
+// consistent with concurrent IncrementRetry calls, and mutating it does not
+// affect the error. Use Retry for the live value.
+func (e *Error) RetryInfo() (*RetryInfo, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.retry == nil {
+		return nil, false
+	}
+
+	snapshot := *e.retry
+
+	return &snapshot, true
+}
+
+// RetriesRemaining returns how many attempts are left before MaxAttempts is
+// reached, or 0 when no retry information is attached. It does not consult
+// ShouldRetry or MaxElapsed; use CanRetry for the full decision.
+func (e *Error) RetriesRemaining() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.retry == nil {
+		return 0
+	}
+
+	return max(e.retry.MaxAttempts-e.retry.CurrentAttempt, 0)
+}
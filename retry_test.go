@@ -1,6 +1,8 @@
 package ewrap
 
 import (
+	"context"
+	"net"
 	"testing"
 	"time"
 )
@@ -150,3 +152,204 @@ func TestIncrementRetry(t *testing.T) {
 		err.IncrementRetry() // Should not panic
 	})
 }
+
+func TestWithMaxElapsed(t *testing.T) {
+	t.Parallel()
+
+	err := New(msgTestError, WithRetry(defaultMaxAttempts, time.Millisecond, WithMaxElapsed(time.Minute)))
+
+	if !err.CanRetry() {
+		t.Fatal("expected CanRetry within the time budget")
+	}
+
+	// Pretend the first attempt was long ago; attempts still remain.
+	err.Retry().firstAttempt = time.Now().Add(-time.Hour)
+
+	if err.CanRetry() {
+		t.Error("CanRetry must be false once MaxElapsed is exceeded")
+	}
+
+	if err.Retry().CurrentAttempt >= err.Retry().MaxAttempts {
+		t.Fatal("test must stop on the budget, not on attempts")
+	}
+}
+
+func TestWithContextTimeoutFromDeadline(t *testing.T) {
+	t.Parallel()
+
+	const (
+		delay       = 20 * time.Millisecond
+		maxAttempts = 100
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*delay)
+	defer cancel()
+
+	err := New(msgTestError, WithRetry(maxAttempts, delay, WithContextTimeoutFromDeadline(ctx)))
+
+	attempts := 0
+	for err.CanRetry() {
+		time.Sleep(err.Retry().NextDelay())
+		err.IncrementRetry()
+
+		attempts++
+	}
+
+	if attempts == 0 || attempts >= maxAttempts {
+		t.Fatalf("expected the deadline to stop retrying early, got %d attempts", attempts)
+	}
+
+	if ctx.Err() != nil {
+		t.Error("retrying must stop before sleeping past the deadline")
+	}
+
+	noDeadline := New(msgTestError,
+		WithRetry(defaultMaxAttempts, delay, WithContextTimeoutFromDeadline(context.Background())))
+	if !noDeadline.Retry().Deadline.IsZero() || !noDeadline.CanRetry() {
+		t.Error("a context without a deadline must not limit retrying")
+	}
+}
+
+func TestDeadlineAccountsForJitter(t *testing.T) {
+	t.Parallel()
+
+	const delay = time.Second
+
+	// The deadline leaves room for Delay but not for Delay stretched by
+	// the full jitter.
+	ctx, cancel := context.WithTimeout(context.Background(), delay+delay/5)
+	defer cancel()
+
+	plain := New(msgTestError,
+		WithRetry(defaultMaxAttempts, delay, WithContextTimeoutFromDeadline(ctx)))
+	if !plain.CanRetry() {
+		t.Error("CanRetry must be true while Delay fits before the deadline")
+	}
+
+	jittered := New(msgTestError,
+		WithRetry(defaultMaxAttempts, delay, WithJitter(0.5), WithContextTimeoutFromDeadline(ctx)))
+	if jittered.CanRetry() {
+		t.Error("CanRetry must be false when a jittered delay can pass the deadline")
+	}
+}
+
+func TestWithJitter(t *testing.T) {
+	t.Parallel()
+
+	const (
+		delay   = 100 * time.Millisecond
+		jitter  = 0.5
+		samples = 200
+	)
+
+	ri := New(msgTestError, WithRetry(defaultMaxAttempts, delay, WithJitter(jitter))).Retry()
+
+	seen := make(map[time.Duration]struct{}, samples)
+
+	for range samples {
+		d := ri.NextDelay()
+		if d < delay/2 || d > delay*3/2 {
+			t.Fatalf("NextDelay %v outside [%v, %v]", d, delay/2, delay*3/2)
+		}
+
+		seen[d] = struct{}{}
+	}
+
+	if len(seen) < 2 {
+		t.Error("jitter must perturb the delay")
+	}
+
+	plain := New(msgTestError, WithRetry(defaultMaxAttempts, delay)).Retry()
+	if plain.NextDelay() != delay {
+		t.Errorf("without jitter NextDelay must equal Delay, got %v", plain.NextDelay())
+	}
+
+	if clamped := New(msgTestError, WithRetry(1, delay, WithJitter(7))).Retry(); clamped.Jitter != 1 {
+		t.Errorf("Jitter must be clamped to 1, got %v", clamped.Jitter)
+	}
+}
+
+func TestRetryInfoSnapshot(t *testing.T) {
+	t.Parallel()
+
+	err := New(msgTestError, WithRetry(defaultMaxAttempts, time.Second))
+	err.IncrementRetry()
+
+	info, ok := err.RetryInfo()
+	if !ok {
+		t.Fatal("expected retry info")
+	}
+
+	if info.CurrentAttempt != 1 {
+		t.Errorf("CurrentAttempt: got %d, want 1", info.CurrentAttempt)
+	}
+
+	if got := err.RetriesRemaining(); got != defaultMaxAttempts-1 {
+		t.Errorf("RetriesRemaining: got %d, want %d", got, defaultMaxAttempts-1)
+	}
+
+	info.CurrentAttempt = 99
+	info.MaxAttempts = 0
+
+	if live := err.Retry(); live.CurrentAttempt != 1 || live.MaxAttempts != defaultMaxAttempts {
+		t.Errorf("mutating the snapshot changed the error: %+v", live)
+	}
+
+	for range defaultMaxAttempts + 1 {
+		err.IncrementRetry()
+	}
+
+	if got := err.RetriesRemaining(); got != 0 {
+		t.Errorf("RetriesRemaining must not go negative, got %d", got)
+	}
+}
+
+func TestRetryInfoAbsent(t *testing.T) {
+	t.Parallel()
+
+	err := New(msgTestError)
+
+	if info, ok := err.RetryInfo(); ok || info != nil {
+		t.Errorf("RetryInfo: got %v, %v", info, ok)
+	}
+
+	if got := err.RetriesRemaining(); got != 0 {
+		t.Errorf("RetriesRemaining: got %d, want 0", got)
+	}
+}
+
+func TestDefaultShouldRetryStdlibCases(t *testing.T) {
+	t.Parallel()
+
+	// CanRetry retries unless a layer says no; IsRetryable needs a layer
+	// that says yes.
+	tests := []struct {
+		name        string
+		err         *Error
+		canRetry    bool
+		isRetryable bool
+	}{
+		{"validation", New(msgTestError, WithType(ErrorTypeValidation), WithRetry(defaultMaxAttempts, 0)), false, false},
+		{"context canceled", Wrap(context.Canceled, msgWrapped, WithRetry(defaultMaxAttempts, 0)), false, false},
+		{"Retryable false", Wrap(retryableError(false), msgWrapped, WithRetry(defaultMaxAttempts, 0)), false, false},
+		{"net timeout", Wrap(timeoutError{}, msgWrapped, WithRetry(defaultMaxAttempts, 0)), true, true},
+		{"unclassified", New(msgTestError, WithRetry(defaultMaxAttempts, 0)), true, false},
+		{"database", New(msgTestError, WithType(ErrorTypeDatabase), WithRetry(defaultMaxAttempts, 0)), true, false},
+		{"WithRetryable false", New(msgTestError, WithRetryable(false), WithRetry(defaultMaxAttempts, 0)), false, false},
+		{"WithRetryable true", New(msgTestError, WithRetryable(true), WithRetry(defaultMaxAttempts, 0)), true, true},
+		{"outer WithRetryable false", Wrap(timeoutError{}, msgWrapped,
+			WithRetryable(false), WithRetry(defaultMaxAttempts, 0)), false, false},
+		{"net non-timeout", Wrap(&net.OpError{Op: "dial", Err: errPlain}, msgWrapped,
+			WithRetry(defaultMaxAttempts, 0)), true, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.err.CanRetry(); got != tt.canRetry {
+			t.Errorf("%s: CanRetry got %v, want %v", tt.name, got, tt.canRetry)
+		}
+
+		if got := IsRetryable(tt.err); got != tt.isRetryable {
+			t.Errorf("%s: IsRetryable got %v, want %v", tt.name, got, tt.isRetryable)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+// Package sentry converts ewrap errors into Sentry events. It is a separate
+// module, so sentry-go is not a dependency of the core ewrap module.
+package sentry
@@ -0,0 +1,17 @@
+module github.com/hyp3rd/ewrap/sentry
+
+go 1.26.4
+
+require (
+	github.com/getsentry/sentry-go v0.43.0
+	github.com/hyp3rd/ewrap v0.0.0-00010101000000-000000000000
+)
+
+require (
+	github.com/goccy/go-json v0.10.6 // indirect
+	golang.org/x/sys v0.30.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/hyp3rd/ewrap => ../
@@ -0,0 +1,156 @@
+package sentry
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	sentrygo "github.com/getsentry/sentry-go"
+
+	"github.com/hyp3rd/ewrap"
+)
+
+// maxErrorDepth bounds how many chain links become exceptions, matching
+// sentry-go's own default.
+const maxErrorDepth = 100
+
+// ToEvent converts err into a Sentry event ready for (*sentry.Hub).CaptureEvent.
+// Every link of the chain becomes an exception, innermost first as Sentry
+// expects, and ewrap layers carry their stack trace. Level and Tags come from
+// the outermost ErrorContext, Extra from its metadata. It returns nil for a
+// nil error.
+func ToEvent(err error) *sentrygo.Event {
+	if err == nil {
+		return nil
+	}
+
+	event := sentrygo.NewEvent()
+	event.Message = err.Error()
+	event.Level = sentrygo.LevelError
+	event.Exception = exceptions(err)
+
+	var ewrapErr *ewrap.Error
+	if !errors.As(err, &ewrapErr) {
+		return event
+	}
+
+	if ctx := ewrapErr.GetErrorContext(); ctx != nil {
+		event.Level = level(ctx.Severity)
+		addContextTags(event.Tags, ctx)
+	}
+
+	if code := ewrapErr.Code(); code != "" {
+		event.Tags["code"] = code.String()
+	}
+
+	for key, val := range ewrapErr.Metadata() {
+		event.Extra[key] = val
+	}
+
+	return event
+}
+
+// exceptions lists every link of err's chain, innermost first.
+func exceptions(err error) []sentrygo.Exception {
+	var out []sentrygo.Exception
+
+	for cur := err; cur != nil && len(out) < maxErrorDepth; cur = errors.Unwrap(cur) {
+		exception := sentrygo.Exception{
+			Type:  reflect.TypeOf(cur).String(),
+			Value: cur.Error(),
+		}
+
+		if ewrapErr, ok := cur.(*ewrap.Error); ok {
+			if ctx := ewrapErr.GetErrorContext(); ctx != nil {
+				exception.Type = fmt.Sprintf("%s (%s)", exception.Type, ctx.Type)
+			}
+
+			exception.Stacktrace = stacktrace(ewrapErr.GetStackFrames())
+		}
+
+		out = append(out, exception)
+	}
+
+	// Sentry reads the exception list from the root cause outwards.
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+
+	return out
+}
+
+// stacktrace converts ewrap frames, innermost first, into a Sentry
+// stacktrace, whose frames run from the outermost call inwards.
+func stacktrace(frames []ewrap.StackFrame) *sentrygo.Stacktrace {
+	if len(frames) == 0 {
+		return nil
+	}
+
+	out := make([]sentrygo.Frame, len(frames))
+
+	for i, frame := range frames {
+		module, function := splitFunction(frame.Function)
+
+		out[len(frames)-1-i] = sentrygo.Frame{
+			Function: function,
+			Module:   module,
+			Filename: frame.File,
+			AbsPath:  frame.File,
+			Lineno:   frame.Line,
+			InApp:    !frame.IsStdlib(),
+		}
+	}
+
+	return &sentrygo.Stacktrace{Frames: out}
+}
+
+// splitFunction splits a fully qualified function name such as
+// "github.com/org/pkg.(*T).Method" into its import path and the rest.
+func splitFunction(name string) (module, function string) {
+	slash := strings.LastIndex(name, "/")
+
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return "", name
+	}
+
+	dot += slash + 1
+
+	return name[:dot], name[dot+1:]
+}
+
+// level maps an ewrap severity onto the closest Sentry level.
+func level(severity ewrap.Severity) sentrygo.Level {
+	switch severity {
+	case ewrap.SeverityInfo:
+		return sentrygo.LevelInfo
+	case ewrap.SeverityWarning:
+		return sentrygo.LevelWarning
+	case ewrap.SeverityCritical:
+		return sentrygo.LevelFatal
+	case ewrap.SeverityError:
+		return sentrygo.LevelError
+	default:
+		return sentrygo.LevelError
+	}
+}
+
+// addContextTags copies the non-empty ErrorContext fields into tags.
+func addContextTags(tags map[string]string, ctx *ewrap.ErrorContext) {
+	tags["error_type"] = ctx.Type.String()
+	tags["severity"] = ctx.Severity.String()
+
+	for key, val := range map[string]string{
+		"component":   ctx.Component,
+		"operation":   ctx.Operation,
+		"request_id":  ctx.RequestID,
+		"user":        ctx.User,
+		"environment": ctx.Environment,
+		"version":     ctx.Version,
+	} {
+		if val != "" {
+			tags[key] = val
+		}
+	}
+}
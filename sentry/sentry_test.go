@@ -0,0 +1,125 @@
+package sentry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	sentrygo "github.com/getsentry/sentry-go"
+
+	"github.com/hyp3rd/ewrap"
+)
+
+var errConnRefused = errors.New("connection refused")
+
+func TestToEventWrappedCritical(t *testing.T) {
+	t.Parallel()
+
+	inner := ewrap.Wrap(errConnRefused, "querying orders",
+		ewrap.WithContext(context.Background(), ewrap.ErrorTypeDatabase, ewrap.SeverityCritical),
+		ewrap.WithCode("DB_DOWN"),
+	).WithMetadata("table", "orders")
+	outer := ewrap.Wrap(inner, "loading dashboard")
+
+	event := ToEvent(outer)
+
+	if event.Level != sentrygo.LevelFatal {
+		t.Errorf("expected level %q, got %q", sentrygo.LevelFatal, event.Level)
+	}
+
+	if event.Message != outer.Error() {
+		t.Errorf("expected message %q, got %q", outer.Error(), event.Message)
+	}
+
+	if len(event.Exception) != 3 {
+		t.Fatalf("expected one exception per chain link, got %d", len(event.Exception))
+	}
+
+	root, top := event.Exception[0], event.Exception[2]
+	if root.Value != errConnRefused.Error() || root.Stacktrace != nil {
+		t.Errorf("expected the plain root cause first, got %+v", root)
+	}
+
+	if top.Value != outer.Error() {
+		t.Errorf("expected the outermost error last, got %q", top.Value)
+	}
+
+	if top.Stacktrace == nil || len(top.Stacktrace.Frames) != len(outer.GetStackFrames()) {
+		t.Fatalf("expected %d frames on the outermost exception, got %+v", len(outer.GetStackFrames()), top.Stacktrace)
+	}
+
+	last := top.Stacktrace.Frames[len(top.Stacktrace.Frames)-1]
+	if last.Function != "TestToEventWrappedCritical" || !last.InApp {
+		t.Errorf("expected the wrap site as the innermost frame, got %+v", last)
+	}
+
+	for key, want := range map[string]string{
+		"error_type": ewrap.ErrorTypeDatabase.String(),
+		"severity":   ewrap.SeverityCritical.String(),
+		"code":       "DB_DOWN",
+	} {
+		if got := event.Tags[key]; got != want {
+			t.Errorf("tag %q: expected %q, got %q", key, want, got)
+		}
+	}
+
+	if event.Extra["table"] != "orders" {
+		t.Errorf("expected metadata in Extra, got %v", event.Extra)
+	}
+}
+
+func TestToEventLevels(t *testing.T) {
+	t.Parallel()
+
+	cases := map[ewrap.Severity]sentrygo.Level{
+		ewrap.SeverityInfo:     sentrygo.LevelInfo,
+		ewrap.SeverityWarning:  sentrygo.LevelWarning,
+		ewrap.SeverityError:    sentrygo.LevelError,
+		ewrap.SeverityCritical: sentrygo.LevelFatal,
+	}
+
+	for severity, want := range cases {
+		err := ewrap.New("boom", ewrap.WithContext(context.Background(), ewrap.ErrorTypeInternal, severity))
+		if got := ToEvent(err).Level; got != want {
+			t.Errorf("%s: expected level %q, got %q", severity, want, got)
+		}
+	}
+}
+
+func TestToEventPlainError(t *testing.T) {
+	t.Parallel()
+
+	if ToEvent(nil) != nil {
+		t.Error("expected nil event for a nil error")
+	}
+
+	event := ToEvent(fmt.Errorf("dialing: %w", errConnRefused))
+
+	if event.Level != sentrygo.LevelError || len(event.Exception) != 2 {
+		t.Fatalf("expected an error-level event with two exceptions, got %+v", event)
+	}
+
+	if !strings.HasPrefix(event.Exception[1].Type, "*fmt.") {
+		t.Errorf("expected the wrapper's Go type, got %q", event.Exception[1].Type)
+	}
+}
+
+func TestSplitFunction(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string][2]string{
+		"github.com/org/pkg.(*T).Method": {"github.com/org/pkg", "(*T).Method"},
+		"main.main":                      {"main", "main"},
+		"runtime.goexit":                 {"runtime", "goexit"},
+		"nodot":                          {"", "nodot"},
+	}
+
+	for name, want := range cases {
+		module, function := splitFunction(name)
+		if module != want[0] || function != want[1] {
+			t.Errorf("%s: expected %v, got [%s %s]", name, want, module, function)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package ewrap
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// defaultSlackStackFrames is how many stack frames ToSlackMessage keeps
+	// unless overridden with WithSlackStackFrames.
+	defaultSlackStackFrames = 5
+	// slackHeaderMaxLen is Slack's limit for plain_text header blocks.
+	slackHeaderMaxLen = 150
+
+	slackColorCritical = "#E01E5A"
+	slackColorError    = "#E8912D"
+	slackColorWarning  = "#ECB22E"
+	slackColorInfo     = "#36C5F0"
+)
+
+// slackMessage is the Slack webhook payload produced by ToSlackMessage. The
+// blocks sit inside an attachment because attachments are the only place
+// Slack honors a color bar.
+type slackMessage struct {
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments"`
+}
+
+type slackAttachment struct {
+	Color  string       `json:"color"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type   string       `json:"type"`
+	Text   *slackText   `json:"text,omitempty"`
+	Fields []*slackText `json:"fields,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// WithSlackStackFrames sets how many stack frames ToSlackMessage includes;
+// the rest are summarized in a trailing line so payloads stay within Slack's
+// size limits. Values below 1 are ignored. Other serializers ignore it.
+func WithSlackStackFrames(n int) FormatOption {
+	return func(eo *ErrorOutput) {
+		if n > 0 {
+			eo.slackStackFrames = n
+		}
+	}
+}
+
+// ToSlackMessage renders the error as a Slack Block Kit webhook payload: a
+// header with the message, the type and severity as fields, and the top of
+// the stack trace in a code block. The attachment color reflects severity
+// (critical red, error orange, warning yellow, info blue). Format options
+// apply as for ToJSON, so WithStackTrace(false) drops the stack block.
+func (e *Error) ToSlackMessage(opts ...FormatOption) (string, error) {
+	output := e.toErrorOutput(withDefaultFormatOptions(opts)...)
+
+	blocks := []slackBlock{
+		{
+			Type: "header",
+			Text: &slackText{Type: "plain_text", Text: truncateRunes(e.Error(), slackHeaderMaxLen)},
+		},
+		{
+			Type: "section",
+			Fields: []*slackText{
+				{Type: "mrkdwn", Text: "*Type*\n" + output.Type},
+				{Type: "mrkdwn", Text: "*Severity*\n" + output.Severity},
+			},
+		},
+	}
+
+	if output.Stack != "" {
+		frames := output.slackStackFrames
+		if frames == 0 {
+			frames = defaultSlackStackFrames
+		}
+
+		blocks = append(blocks, slackBlock{
+			Type: "section",
+			Text: &slackText{Type: "mrkdwn", Text: "```" + truncateStackFrames(output.Stack, frames) + "```"},
+		})
+	}
+
+	msg := slackMessage{
+		Text: e.Error(),
+		Attachments: []slackAttachment{
+			{Color: slackSeverityColor(output.Severity), Blocks: blocks},
+		},
+	}
+
+	data, err := jsonMarshaler().Marshal(msg)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal error to Slack message: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// slackSeverityColor maps a serialized severity to an attachment color.
+func slackSeverityColor(severity string) string {
+	switch severity {
+	case severityCriticalStr:
+		return slackColorCritical
+	case severityWarningStr:
+		return slackColorWarning
+	case severityInfoStr:
+		return slackColorInfo
+	default:
+		return slackColorError
+	}
+}
+
+// truncateStackFrames keeps the first n lines of a formatted stack and
+// notes how many were dropped.
+func truncateStackFrames(stack string, n int) string {
+	lines := strings.Split(strings.TrimRight(stack, "\n"), "\n")
+	if len(lines) <= n {
+		return strings.Join(lines, "\n")
+	}
+
+	return strings.Join(lines[:n], "\n") + fmt.Sprintf("\n... %d more frames", len(lines)-n)
+}
+
+// truncateRunes shortens s to at most limit runes, marking the cut with an
+// ellipsis.
+func truncateRunes(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+
+	return string(runes[:limit-1]) + "…"
+}
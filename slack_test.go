@@ -0,0 +1,117 @@
+package ewrap
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+func decodeSlackMessage(t *testing.T, err *Error, opts ...FormatOption) slackMessage {
+	t.Helper()
+
+	payload, serr := err.ToSlackMessage(opts...)
+	if serr != nil {
+		t.Fatalf(unexpectedErrFn, serr)
+	}
+
+	var msg slackMessage
+
+	uerr := json.Unmarshal([]byte(payload), &msg)
+	if uerr != nil {
+		t.Fatalf("invalid Slack payload: %v", uerr)
+	}
+
+	return msg
+}
+
+func TestToSlackMessageStructure(t *testing.T) {
+	t.Parallel()
+
+	err := Wrap(errRoot, msgWrapped, WithContext(context.Background(), ErrorTypeNetwork, SeverityCritical))
+
+	msg := decodeSlackMessage(t, err)
+
+	if msg.Text != err.Error() {
+		t.Errorf("fallback text: got %q, want %q", msg.Text, err.Error())
+	}
+
+	if len(msg.Attachments) != 1 {
+		t.Fatalf("expected one attachment, got %d", len(msg.Attachments))
+	}
+
+	blocks := msg.Attachments[0].Blocks
+	if len(blocks) != 3 {
+		t.Fatalf("expected header, fields and stack blocks, got %d", len(blocks))
+	}
+
+	if blocks[0].Type != "header" || blocks[0].Text.Text != err.Error() {
+		t.Errorf("unexpected header block: %+v", blocks[0])
+	}
+
+	if len(blocks[1].Fields) != 2 ||
+		blocks[1].Fields[0].Text != "*Type*\n"+typeNetworkStr ||
+		blocks[1].Fields[1].Text != "*Severity*\n"+severityCriticalStr {
+		t.Errorf("unexpected fields block: %+v", blocks[1])
+	}
+
+	if stack := blocks[2].Text.Text; !strings.HasPrefix(stack, "```") || !strings.HasSuffix(stack, "```") {
+		t.Errorf("expected stack in a code block, got %q", stack)
+	}
+}
+
+func TestToSlackMessageSeverityColor(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		severity Severity
+		color    string
+	}{
+		{SeverityCritical, slackColorCritical},
+		{SeverityError, slackColorError},
+		{SeverityWarning, slackColorWarning},
+		{SeverityInfo, slackColorInfo},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.severity.String(), func(t *testing.T) {
+			t.Parallel()
+
+			msg := decodeSlackMessage(t, New(msgTestError, WithSeverity(tc.severity)))
+
+			if got := msg.Attachments[0].Color; got != tc.color {
+				t.Errorf("color: got %q, want %q", got, tc.color)
+			}
+		})
+	}
+}
+
+func TestToSlackMessageStackOptions(t *testing.T) {
+	t.Parallel()
+
+	err := New(msgTestError)
+
+	if blocks := decodeSlackMessage(t, err, WithStackTrace(false)).Attachments[0].Blocks; len(blocks) != 2 {
+		t.Errorf("expected stack block to be dropped, got %d blocks", len(blocks))
+	}
+
+	stack := decodeSlackMessage(t, err, WithSlackStackFrames(1)).Attachments[0].Blocks[2].Text.Text
+	if strings.Count(strings.Trim(stack, "`"), " - ") != 1 || !strings.Contains(stack, "more frames") {
+		t.Errorf("expected a single frame plus an omission note, got %q", stack)
+	}
+}
+
+func TestTruncateStackFrames(t *testing.T) {
+	t.Parallel()
+
+	const stack = "a.go:1 - a\nb.go:2 - b\nc.go:3 - c\n"
+
+	if got := truncateStackFrames(stack, 5); got != "a.go:1 - a\nb.go:2 - b\nc.go:3 - c" {
+		t.Errorf("short stack should be kept whole, got %q", got)
+	}
+
+	if got := truncateStackFrames(stack, 2); got != "a.go:1 - a\nb.go:2 - b\n... 1 more frames" {
+		t.Errorf("unexpected truncation: %q", got)
+	}
+}
@@ -0,0 +1,154 @@
+package ewrap
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// sortedErrorOutput mirrors ErrorOutput with metadata held as an ordered
+// list, so the encoders cannot reorder its keys.
+type sortedErrorOutput struct {
+	SchemaVersion string              `json:"schema_version,omitempty" yaml:"schema_version,omitempty"`
+	Message       string              `json:"message"                  yaml:"message"`
+	Timestamp     string              `json:"timestamp"                yaml:"timestamp"`
+	Type          string              `json:"type"                     yaml:"type"`
+	Severity      string              `json:"severity"                 yaml:"severity"`
+	Code          string              `json:"code,omitempty"           yaml:"code,omitempty"`
+	Tags          []string            `json:"tags,omitempty"           yaml:"tags,omitempty"`
+	Stack         string              `json:"stack"                    yaml:"stack"`
+	Fingerprint   string              `json:"fingerprint,omitempty"    yaml:"fingerprint,omitempty"`
+	Cause         *sortedErrorOutput  `json:"cause,omitempty"          yaml:"cause,omitempty"`
+	Context       orderedMap          `json:"context,omitempty"        yaml:"context,omitempty"`
+	Metadata      orderedMap          `json:"metadata,omitempty"       yaml:"metadata,omitempty"`
+	Recovery      *RecoverySuggestion `json:"recovery,omitempty"       yaml:"recovery,omitempty"`
+	Depth         int                 `json:"depth"                    yaml:"depth"`
+}
+
+// marshalTarget returns the value ToJSON and ToYAML should encode: the
+// output itself, or its key-ordered mirror when WithSortedMetadata was set.
+func (eo *ErrorOutput) marshalTarget() any {
+	if !eo.sortMetadata {
+		return eo
+	}
+
+	return eo.sorted()
+}
+
+// sorted converts the output and its cause chain to sortedErrorOutput.
+func (eo *ErrorOutput) sorted() *sortedErrorOutput {
+	if eo == nil {
+		return nil
+	}
+
+	return &sortedErrorOutput{
+		SchemaVersion: eo.SchemaVersion,
+		Message:       eo.Message,
+		Timestamp:     eo.Timestamp,
+		Type:          eo.Type,
+		Severity:      eo.Severity,
+		Code:          eo.Code,
+		Tags:          eo.Tags,
+		Stack:         eo.Stack,
+		Fingerprint:   eo.Fingerprint,
+		Cause:         eo.Cause.sorted(),
+		Context:       newOrderedMap(eo.Context),
+		Metadata:      newOrderedMap(eo.Metadata),
+		Recovery:      eo.Recovery,
+		Depth:         eo.Depth,
+	}
+}
+
+// orderedEntry is a single key/value pair of an orderedMap.
+type orderedEntry struct {
+	key   string
+	value any
+}
+
+// orderedMap is a string-keyed map flattened to a slice sorted by key.
+// Nested map[string]any values are converted recursively.
+type orderedMap []orderedEntry
+
+// newOrderedMap returns m's entries sorted by key, or nil for an empty map
+// so omitempty still drops the field.
+func newOrderedMap(m map[string]any) orderedMap {
+	if len(m) == 0 {
+		return nil
+	}
+
+	out := make(orderedMap, 0, len(m))
+
+	for k, v := range m {
+		if nested, ok := v.(map[string]any); ok {
+			v = newOrderedMap(nested)
+		}
+
+		out = append(out, orderedEntry{key: k, value: v})
+	}
+
+	slices.SortFunc(out, func(a, b orderedEntry) int {
+		return cmp.Compare(a.key, b.key)
+	})
+
+	return out
+}
+
+// MarshalJSON implements json.Marshaler, writing entries in slice order.
+func (om orderedMap) MarshalJSON() ([]byte, error) {
+	if om == nil {
+		return []byte("{}"), nil
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+
+	for i, entry := range om {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := jsonMarshaler().Marshal(entry.key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal metadata key %q: %w", entry.key, err)
+		}
+
+		value, err := jsonMarshaler().Marshal(entry.value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal metadata value for %q: %w", entry.key, err)
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// MarshalYAML implements yaml.Marshaler, emitting a mapping node whose
+// entries keep slice order.
+func (om orderedMap) MarshalYAML() (any, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+	for _, entry := range om {
+		var value yaml.Node
+
+		err := value.Encode(entry.value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal metadata value for %q: %w", entry.key, err)
+		}
+
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: entry.key},
+			&value,
+		)
+	}
+
+	return node, nil
+}
@@ -1,7 +1,11 @@
 package ewrap
 
 import (
+	"fmt"
 	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 // StackFrame represents a single frame in a stack trace.
@@ -16,9 +20,80 @@ type StackFrame struct {
 	PC uintptr `json:"pc" yaml:"pc"`
 }
 
+// IsRuntime reports whether the frame belongs to the Go runtime, such as
+// runtime.goexit or runtime/debug.Stack, which UIs usually hide.
+func (f StackFrame) IsRuntime() bool {
+	pkg := f.pkg()
+
+	return pkg == "runtime" || strings.HasPrefix(pkg, "runtime/") || strings.HasPrefix(pkg, "internal/runtime/")
+}
+
+// IsStdlib reports whether the frame belongs to the standard library, the
+// runtime included. Standard library import paths have no dot in their first
+// element; package main is excluded. A module whose path has no dot either
+// looks like the standard library too, so use IsApp with the module path to
+// tell application frames apart precisely.
+func (f StackFrame) IsStdlib() bool {
+	pkg := f.pkg()
+	if pkg == "" || pkg == "main" {
+		return false
+	}
+
+	first, _, _ := strings.Cut(pkg, "/")
+
+	return !strings.Contains(first, ".")
+}
+
+// IsApp reports whether the frame belongs to the module at modulePath, such
+// as "github.com/org/service", or to one of its packages. With an empty
+// modulePath it reports any frame outside the standard library.
+func (f StackFrame) IsApp(modulePath string) bool {
+	if modulePath == "" {
+		return f.Function != "" && !f.IsStdlib()
+	}
+
+	return strings.HasPrefix(f.Function, modulePath+".") || strings.HasPrefix(f.Function, modulePath+"/")
+}
+
+// pkg returns the import path part of the frame's function name: everything
+// before the first dot after the last slash, so "net/http.(*Client).Do" gives
+// "net/http".
+func (f StackFrame) pkg() string {
+	slash := strings.LastIndex(f.Function, "/")
+
+	dot := strings.Index(f.Function[slash+1:], ".")
+	if dot < 0 {
+		return ""
+	}
+
+	return f.Function[:slash+1+dot]
+}
+
 // StackTrace represents a collection of stack frames.
 type StackTrace []StackFrame
 
+// String renders the frames one per line in the same "file:line - function"
+// layout as (*Error).Stack.
+func (st StackTrace) String() string {
+	var builder strings.Builder
+
+	for _, frame := range st {
+		_, _ = fmt.Fprintf(&builder, "%s:%d - %s\n", frame.File, frame.Line, frame.Function)
+	}
+
+	return builder.String()
+}
+
+// JSON encodes the frames as a JSON array using the package JSON marshaler.
+func (st StackTrace) JSON() ([]byte, error) {
+	data, err := jsonMarshaler().Marshal(st)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal stack trace to JSON: %w", err)
+	}
+
+	return data, nil
+}
+
 // StackIterator provides a way to iterate through stack frames.
 type StackIterator struct {
 	frames []StackFrame
@@ -30,7 +105,9 @@ func NewStackIterator(pcs []uintptr) *StackIterator {
 	frames := make([]StackFrame, 0, len(pcs))
 	callersFrames := runtime.CallersFrames(pcs)
 
-	for {
+	// CallersFrames yields one zero Frame for an empty slice; skip it so
+	// errors without a stack report no frames.
+	for len(pcs) > 0 {
 		frame, more := callersFrames.Next()
 
 		if !isInternalFrame(frame) {
@@ -75,6 +152,33 @@ func (si *StackIterator) Reset() {
 	si.index = 0
 }
 
+// Skip advances the iterator past the next n frames, stopping at the end.
+// Negative values are ignored; Reset rewinds as usual.
+func (si *StackIterator) Skip(n int) {
+	if n <= 0 {
+		return
+	}
+
+	si.index = min(si.index+n, len(si.frames))
+}
+
+// Filter returns a new iterator, positioned at its start, over the remaining
+// frames for which pred returns true. The receiver's position is unchanged.
+func (si *StackIterator) Filter(pred func(StackFrame) bool) *StackIterator {
+	var frames []StackFrame
+
+	for _, frame := range si.Frames() {
+		if pred(frame) {
+			frames = append(frames, frame)
+		}
+	}
+
+	return &StackIterator{
+		frames: frames,
+		index:  0,
+	}
+}
+
 // Frames returns all remaining frames as a slice.
 func (si *StackIterator) Frames() []StackFrame {
 	if si.index >= len(si.frames) {
@@ -89,9 +193,43 @@ func (si *StackIterator) AllFrames() []StackFrame {
 	return si.frames
 }
 
-// GetStackIterator returns a stack iterator for the error's stack trace.
+// GetStackIterator returns a stack iterator for the error's stack trace,
+// honoring WithCallerSkip.
 func (e *Error) GetStackIterator() *StackIterator {
-	return NewStackIterator(e.stack)
+	iterator := NewStackIterator(e.stack)
+	iterator.frames = iterator.frames[min(e.callerSkip, len(iterator.frames)):]
+
+	return iterator
+}
+
+// WithStack replaces the error's stack with one captured at the call site,
+// for errors built far from where they become meaningful. The cached Stack()
+// string is reset, so call it before the error is shared across goroutines.
+func (e *Error) WithStack() *Error {
+	e.stack = capturePCs(callerSkipNew, defaultStackDepth)
+	e.stackOnce = sync.Once{}
+	e.stackStr = ""
+
+	return e
+}
+
+// DetachStack drops the captured stack so its program counters can be
+// garbage collected, for errors kept around long after they were logged or
+// serialized. Afterwards Stack() is empty and serialized output has no
+// frames. Like WithStack it mutates the error in place, so call it only once
+// nothing else is reading the error.
+func (e *Error) DetachStack() *Error {
+	e.stack = nil
+	e.stackOnce = sync.Once{}
+	e.stackStr = ""
+
+	return e
+}
+
+// StackTrace returns the error's stack as a typed StackTrace, with the same
+// frames as GetStackFrames.
+func (e *Error) StackTrace() StackTrace {
+	return e.GetStackFrames()
 }
 
 // GetStackFrames returns all stack frames as a slice.
@@ -100,3 +238,65 @@ func (e *Error) GetStackFrames() []StackFrame {
 
 	return iterator.AllFrames()
 }
+
+// StackCapturer records the program counters stored on each error. Replace
+// the default, which uses runtime.Callers, with SetStackCapturer to plug in
+// a cheaper or custom unwinder, or deterministic stacks for tests.
+type StackCapturer interface {
+	// Capture returns program counters, skipping skip frames first; skip 0
+	// identifies the caller of Capture. ewrap keeps at most the error's
+	// stack depth (32, or the WithStackDepth value) and drops the rest.
+	// Implementations must be goroutine-safe.
+	Capture(skip int) []uintptr
+}
+
+// callersCaptureSkip accounts for runtime.Callers and Capture itself.
+const callersCaptureSkip = 2
+
+// callersCapturer is the default StackCapturer, backed by runtime.Callers.
+// capturePCs builds one per capture with the requested depth, so
+// WithStackDepth sizes the buffer instead of a package-wide limit.
+type callersCapturer struct {
+	depth int
+}
+
+// Capture implements StackCapturer. The slice is sized to depth so callers
+// with shallow stacks don't carry empty trailing slots.
+func (c callersCapturer) Capture(skip int) []uintptr {
+	pcs := make([]uintptr, c.depth)
+	n := runtime.Callers(skip+callersCaptureSkip, pcs)
+
+	return pcs[:n]
+}
+
+// capturerBox lets atomic.Pointer hold StackCapturer values of differing
+// concrete types.
+type capturerBox struct {
+	capturer StackCapturer
+}
+
+//nolint:gochecknoglobals // package-wide capture backend, swapped atomically
+var stackCapturer atomic.Pointer[capturerBox]
+
+// SetStackCapturer installs c as the source of every stack ewrap captures
+// from then on. Passing nil restores the runtime.Callers default. Errors
+// created earlier keep their stacks. Configure it once at startup.
+func SetStackCapturer(c StackCapturer) {
+	if c == nil {
+		stackCapturer.Store(nil)
+
+		return
+	}
+
+	stackCapturer.Store(&capturerBox{capturer: c})
+}
+
+// currentStackCapturer returns the capturer installed by SetStackCapturer,
+// or the runtime.Callers default sized to depth frames.
+func currentStackCapturer(depth int) StackCapturer {
+	if box := stackCapturer.Load(); box != nil {
+		return box.capturer
+	}
+
+	return callersCapturer{depth: depth}
+}
@@ -1,8 +1,10 @@
 package ewrap
 
 import (
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/goccy/go-json"
 	"gopkg.in/yaml.v3"
@@ -45,6 +47,84 @@ func TestStackIterator(t *testing.T) {
 	}
 }
 
+// newTestIterator builds an iterator over synthetic frames so paging and
+// filtering tests don't depend on the real call stack.
+func newTestIterator() *StackIterator {
+	return &StackIterator{
+		frames: []StackFrame{
+			{Function: "app.handler", File: "/src/app/handler.go", Line: 1},
+			{Function: "lib.call", File: "/src/lib/call.go", Line: 2},
+			{Function: "app.main", File: "/src/app/main.go", Line: 3},
+			{Function: "lib.run", File: "/src/lib/run.go", Line: 4},
+		},
+	}
+}
+
+func TestStackIteratorSkip(t *testing.T) {
+	t.Parallel()
+
+	iterator := newTestIterator()
+
+	iterator.Skip(2)
+
+	if frame := iterator.Next(); frame == nil || frame.Line != 3 {
+		t.Errorf("expected third frame after Skip(2), got %+v", frame)
+	}
+
+	iterator.Skip(-1)
+
+	if frame := iterator.Next(); frame == nil || frame.Line != 4 {
+		t.Errorf("expected negative Skip to be ignored, got %+v", frame)
+	}
+
+	iterator.Reset()
+	iterator.Skip(len(iterator.AllFrames()) + 10)
+
+	if iterator.HasNext() || iterator.Next() != nil {
+		t.Error("expected Skip past the end to exhaust the iterator")
+	}
+
+	iterator.Reset()
+
+	if !iterator.HasNext() {
+		t.Error("expected Reset to rewind after Skip")
+	}
+}
+
+func TestStackIteratorFilter(t *testing.T) {
+	t.Parallel()
+
+	iterator := newTestIterator()
+	inApp := func(frame StackFrame) bool { return strings.Contains(frame.File, "/app/") }
+
+	filtered := iterator.Filter(inApp)
+
+	var lines []int
+	for filtered.HasNext() {
+		lines = append(lines, filtered.Next().Line)
+	}
+
+	if len(lines) != 2 || lines[0] != 1 || lines[1] != 3 {
+		t.Errorf("expected app frames at lines [1 3], got %v", lines)
+	}
+
+	filtered.Reset()
+
+	if !filtered.HasNext() {
+		t.Error("expected Reset to rewind the filtered iterator")
+	}
+
+	iterator.Skip(1)
+
+	if got := iterator.Filter(inApp).AllFrames(); len(got) != 1 || got[0].Line != 3 {
+		t.Errorf("expected Filter to consider only remaining frames, got %+v", got)
+	}
+
+	if frame := iterator.Next(); frame == nil || frame.Line != 2 {
+		t.Errorf("Filter must not move the source iterator, got %+v", frame)
+	}
+}
+
 func TestStackFrameStructure(t *testing.T) {
 	t.Parallel()
 
@@ -175,6 +255,121 @@ func TestErrorGroupYAML(t *testing.T) {
 	}
 }
 
+func TestErrorGroupYAMLCompactStack(t *testing.T) {
+	t.Parallel()
+
+	eg := ErrorGroupFromErrors(Wrap(New(msgOriginal), msgWrapped), errPlain)
+
+	verbose, err := eg.ToYAML()
+	if err != nil {
+		t.Fatalf(unexpectedErrFn, err)
+	}
+
+	compact, err := eg.ToYAML(WithCompactStack())
+	if err != nil {
+		t.Fatalf(unexpectedErrFn, err)
+	}
+
+	var result struct {
+		Errors []struct {
+			StackTrace []string `yaml:"stack_trace"`
+			Cause      *struct {
+				StackTrace []string `yaml:"stack_trace"`
+			} `yaml:"cause"`
+		} `yaml:"errors"`
+	}
+
+	if unmarshalErr := yaml.Unmarshal([]byte(compact), &result); unmarshalErr != nil {
+		t.Fatalf("compact YAML should decode frames as strings: %v\n%s", unmarshalErr, compact)
+	}
+
+	frames := result.Errors[0].StackTrace
+	if len(frames) == 0 || !strings.Contains(frames[0], "stack_test.go:") ||
+		!strings.Contains(frames[0], " github.com/hyp3rd/ewrap.TestErrorGroupYAMLCompactStack") {
+		t.Errorf("expected \"file:line function\" frames, got %q", frames)
+	}
+
+	if result.Errors[0].Cause == nil || len(result.Errors[0].Cause.StackTrace) == 0 {
+		t.Error("causes should use compact frames too")
+	}
+
+	if len(result.Errors[1].StackTrace) != 0 {
+		t.Error("standard errors carry no frames")
+	}
+
+	if len(compact) >= len(verbose) {
+		t.Errorf("compact YAML (%d bytes) should be smaller than the default (%d bytes)", len(compact), len(verbose))
+	}
+
+	jsonStr, err := eg.ToJSON(WithCompactStack())
+	if err != nil {
+		t.Fatalf(unexpectedErrFn, err)
+	}
+
+	if !strings.Contains(jsonStr, `"function":`) {
+		t.Error("JSON should keep structured frames")
+	}
+}
+
+func TestErrorGroupFormatOptions(t *testing.T) {
+	t.Parallel()
+
+	eg := NewErrorGroup()
+	eg.Add(Wrap(New(msgRoot), msgWrapped))
+
+	jsonStr, err := eg.ToJSON(WithStackTrace(false), WithTimestampFormat(dateOnlyLayout))
+	if err != nil {
+		t.Fatalf("Failed to convert to JSON: %v", err)
+	}
+
+	var fromJSON ErrorGroupSerialization
+
+	unmarshalErr := json.Unmarshal([]byte(jsonStr), &fromJSON)
+	if unmarshalErr != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", unmarshalErr)
+	}
+
+	if strings.Contains(jsonStr, "stack_trace") {
+		t.Errorf("expected stack traces to be omitted, got %s", jsonStr)
+	}
+
+	if _, parseErr := time.Parse(dateOnlyLayout, fromJSON.Timestamp); parseErr != nil {
+		t.Errorf("expected timestamp in %s layout, got %q", dateOnlyLayout, fromJSON.Timestamp)
+	}
+
+	yamlStr, err := eg.ToYAML(WithStackTrace(false))
+	if err != nil {
+		t.Fatalf("Failed to convert to YAML: %v", err)
+	}
+
+	if strings.Contains(yamlStr, "stack_trace") {
+		t.Errorf("expected stack traces to be omitted, got %s", yamlStr)
+	}
+
+	withStack, err := eg.ToJSON(WithStackTrace(true))
+	if err != nil {
+		t.Fatalf("Failed to convert to JSON: %v", err)
+	}
+
+	if !strings.Contains(withStack, "stack_trace") {
+		t.Error("expected stack traces when explicitly included")
+	}
+}
+
+func TestErrorGroupToSerializationWithoutStacks(t *testing.T) {
+	t.Parallel()
+
+	eg := ErrorGroupFromErrors(Wrap(New(msgRoot), msgWrapped), New(msgFirst), Wrap(errPlain, msgSecond))
+
+	for i, entry := range eg.ToSerialization(WithStackTrace(false)).Errors {
+		for cur := &entry; cur != nil; cur = cur.Cause {
+			if len(cur.StackTrace) != 0 {
+				t.Errorf("entry %d: expected no stack on %q, got %d frames", i, cur.Message, len(cur.StackTrace))
+			}
+		}
+	}
+}
+
 func TestErrorGroupSerializationWithWrappedErrors(t *testing.T) {
 	t.Parallel()
 
@@ -292,3 +487,194 @@ func benchSerializationYAML(b *testing.B, eg *ErrorGroup) {
 		}
 	}
 }
+
+// recaptureHelper calls WithStack from its own frame.
+//
+//go:noinline
+func recaptureHelper(err *Error) *Error {
+	return err.WithStack()
+}
+
+func TestErrorWithStack(t *testing.T) {
+	t.Parallel()
+
+	err := New(msgTestError)
+	original := err.Stack()
+
+	if got := recaptureHelper(err); got != err {
+		t.Error("expected WithStack to return the same error instance")
+	}
+
+	frames := err.GetStackFrames()
+	if len(frames) == 0 || !strings.HasSuffix(frames[0].Function, ".recaptureHelper") {
+		t.Fatalf("expected top frame to be the caller of WithStack, got %+v", frames)
+	}
+
+	if err.Stack() == original || !strings.Contains(err.Stack(), "recaptureHelper") {
+		t.Errorf("expected cached stack string to be refreshed, got:\n%s", err.Stack())
+	}
+
+	if err.Cause() != nil || err.Error() != msgTestError {
+		t.Error("WithStack must not change message or cause")
+	}
+}
+
+func TestDetachStack(t *testing.T) {
+	t.Parallel()
+
+	err := New(msgTestError)
+
+	before, jsonErr := err.ToJSON()
+	if jsonErr != nil {
+		t.Fatalf(unexpectedErrFn, jsonErr)
+	}
+
+	if err.Stack() == "" || !strings.Contains(before, "TestDetachStack") {
+		t.Fatal("expected frames before detaching")
+	}
+
+	if got := err.DetachStack(); got != err {
+		t.Error("expected DetachStack to return the same error instance")
+	}
+
+	if err.Stack() != "" || len(err.GetStackFrames()) != 0 {
+		t.Errorf("expected no stack after detaching, got %q", err.Stack())
+	}
+
+	after, jsonErr := err.ToJSON()
+	if jsonErr != nil {
+		t.Fatalf(unexpectedErrFn, jsonErr)
+	}
+
+	if strings.Contains(after, "TestDetachStack") {
+		t.Error("serialization after detaching should carry no frames")
+	}
+
+	if !strings.Contains(before, "TestDetachStack") {
+		t.Error("output rendered before detaching must keep its frames")
+	}
+}
+
+// fakeCapturer is a StackCapturer returning fixed program counters.
+type fakeCapturer struct {
+	pcs   []uintptr
+	calls int
+}
+
+func (f *fakeCapturer) Capture(_ int) []uintptr {
+	f.calls++
+
+	return f.pcs
+}
+
+//nolint:paralleltest // mutates the package-level stack capturer
+func TestSetStackCapturer(t *testing.T) {
+	fake := &fakeCapturer{pcs: []uintptr{1, 2, 3}}
+
+	SetStackCapturer(fake)
+	t.Cleanup(func() { SetStackCapturer(nil) })
+
+	err := New(msgTestError)
+	if fake.calls != 1 {
+		t.Fatalf("expected New to call the capturer once, got %d", fake.calls)
+	}
+
+	if !slices.Equal(err.stack, fake.pcs) {
+		t.Errorf("expected stack %v from the capturer, got %v", fake.pcs, err.stack)
+	}
+
+	shallow := New(msgTestError, WithStackDepth(2))
+	if len(shallow.stack) != 2 {
+		t.Errorf("expected the stack to be cut to the requested depth, got %d", len(shallow.stack))
+	}
+
+	SetStackCapturer(nil)
+
+	restored := New(msgTestError)
+	if frames := restored.GetStackFrames(); len(frames) == 0 ||
+		!strings.Contains(frames[0].Function, "TestSetStackCapturer") {
+		t.Errorf("expected the default capturer to start at the caller, got %+v", frames)
+	}
+}
+
+func TestStackTraceString(t *testing.T) {
+	t.Parallel()
+
+	err := New(msgTestError)
+
+	st := err.StackTrace()
+	if len(st) == 0 {
+		t.Fatal("expected frames in StackTrace")
+	}
+
+	if got, want := st.String(), err.Stack(); got != want {
+		t.Errorf("StackTrace().String() differs from Stack():\ngot:  %q\nwant: %q", got, want)
+	}
+
+	if StackTrace(nil).String() != "" {
+		t.Error("expected empty string for an empty StackTrace")
+	}
+}
+
+func TestStackTraceJSON(t *testing.T) {
+	t.Parallel()
+
+	st := New(msgTestError).StackTrace()
+
+	data, err := st.JSON()
+	if err != nil {
+		t.Fatalf("Failed to marshal stack trace: %v", err)
+	}
+
+	var decoded StackTrace
+
+	unmarshalErr := json.Unmarshal(data, &decoded)
+	if unmarshalErr != nil {
+		t.Fatalf("Failed to unmarshal stack trace: %v", unmarshalErr)
+	}
+
+	if len(decoded) != len(st) || decoded[0] != st[0] {
+		t.Errorf("round-trip mismatch: got %+v, want %+v", decoded, st)
+	}
+}
+
+func TestStackFrameClassification(t *testing.T) {
+	t.Parallel()
+
+	const module = "github.com/acme/shop"
+
+	tests := []struct {
+		name                   string
+		function               string
+		runtime, stdlib, isApp bool
+	}{
+		{"runtime", "runtime.goexit", true, true, false},
+		{"runtime subpackage", "runtime/debug.Stack", true, true, false},
+		{"stdlib", "net/http.(*Client).Do", false, true, false},
+		{"app", module + "/orders.(*Service).Place.func1", false, false, true},
+		{"app root package", module + ".Run", false, false, true},
+		{"dependency", "github.com/acme/shopping.Run", false, false, false},
+		{"main", "main.main", false, false, false},
+		{"unknown", "", false, false, false},
+	}
+
+	for _, tt := range tests {
+		frame := StackFrame{Function: tt.function, File: "/src/file.go", Line: 1}
+
+		if got := frame.IsRuntime(); got != tt.runtime {
+			t.Errorf("%s: IsRuntime() = %v, want %v", tt.name, got, tt.runtime)
+		}
+
+		if got := frame.IsStdlib(); got != tt.stdlib {
+			t.Errorf("%s: IsStdlib() = %v, want %v", tt.name, got, tt.stdlib)
+		}
+
+		if got := frame.IsApp(module); got != tt.isApp {
+			t.Errorf("%s: IsApp(%q) = %v, want %v", tt.name, module, got, tt.isApp)
+		}
+	}
+
+	if !(StackFrame{Function: "main.main"}).IsApp("") {
+		t.Error("IsApp(\"\") should report frames outside the standard library")
+	}
+}
@@ -0,0 +1,28 @@
+package ewrap
+
+import "slices"
+
+// WithTags labels the error with string tags such as "timeout" or
+// "external", for classification that needs no value. Tags keep the order
+// they were first added in, and duplicates and empty strings are dropped.
+// Wrap inherits the cause's tags, so tags passed to Wrap merge with them.
+func WithTags(tags ...string) Option {
+	return func(err *Error) {
+		for _, tag := range tags {
+			if tag != "" && !slices.Contains(err.tags, tag) {
+				err.tags = append(err.tags, tag)
+			}
+		}
+	}
+}
+
+// Tags returns a copy of the error's tags, including those inherited
+// through Wrap, or nil if there are none.
+func (e *Error) Tags() []string {
+	return slices.Clone(e.tags)
+}
+
+// HasTag reports whether the error carries tag.
+func (e *Error) HasTag(tag string) bool {
+	return slices.Contains(e.tags, tag)
+}
@@ -0,0 +1,94 @@
+package ewrap
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+const (
+	tagTimeout  = "timeout"
+	tagExternal = "external"
+	tagBilling  = "billing"
+)
+
+func TestWithTagsDedup(t *testing.T) {
+	t.Parallel()
+
+	err := New(msgTestError, WithTags(tagTimeout, tagExternal, tagTimeout, ""), WithTags(tagExternal))
+
+	if got := err.Tags(); !slices.Equal(got, []string{tagTimeout, tagExternal}) {
+		t.Errorf("expected deduplicated tags in order, got %v", got)
+	}
+
+	if !err.HasTag(tagTimeout) || err.HasTag(tagBilling) {
+		t.Error("HasTag disagrees with the attached tags")
+	}
+
+	err.Tags()[0] = tagBilling
+	if err.HasTag(tagBilling) {
+		t.Error("Tags must return a copy")
+	}
+
+	if New(msgPlain).Tags() != nil {
+		t.Error("expected nil tags when none are set")
+	}
+}
+
+func TestWithTagsMergeOnWrap(t *testing.T) {
+	t.Parallel()
+
+	inner := New(msgOriginal, WithTags(tagTimeout, tagExternal))
+	outer := Wrap(inner, msgWrapped, WithTags(tagExternal, tagBilling))
+
+	if got := outer.Tags(); !slices.Equal(got, []string{tagTimeout, tagExternal, tagBilling}) {
+		t.Errorf("expected merged tags, got %v", got)
+	}
+
+	if got := inner.Tags(); !slices.Equal(got, []string{tagTimeout, tagExternal}) {
+		t.Errorf("wrapping must not change the cause's tags, got %v", got)
+	}
+
+	if got := outer.Annotate(msgTest).Tags(); !slices.Equal(got, outer.Tags()) {
+		t.Errorf("Annotate should keep tags, got %v", got)
+	}
+}
+
+func TestWithTagsSerialization(t *testing.T) {
+	t.Parallel()
+
+	err := Wrap(New(msgOriginal, WithTags(tagTimeout)), msgWrapped, WithTags(tagExternal))
+
+	jsonStr, jsonErr := err.ToJSON()
+	if jsonErr != nil {
+		t.Fatalf(unexpectedErrFn, jsonErr)
+	}
+
+	var output ErrorOutput
+	if unmarshalErr := json.Unmarshal([]byte(jsonStr), &output); unmarshalErr != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", unmarshalErr)
+	}
+
+	if !slices.Equal(output.Tags, []string{tagTimeout, tagExternal}) {
+		t.Errorf("expected tags in JSON, got %v", output.Tags)
+	}
+
+	if output.Cause == nil || !slices.Equal(output.Cause.Tags, []string{tagTimeout}) {
+		t.Error("the cause should serialize its own tags")
+	}
+
+	if plain, _ := New(msgPlain).ToJSON(); strings.Contains(plain, `"tags"`) {
+		t.Error("errors without tags should omit the field")
+	}
+
+	yamlStr, yamlErr := err.ToYAML(WithSortedMetadata())
+	if yamlErr != nil {
+		t.Fatalf(unexpectedErrFn, yamlErr)
+	}
+
+	if !strings.Contains(yamlStr, "tags:\n    - timeout\n    - external") {
+		t.Errorf("expected tags in YAML output:\n%s", yamlStr)
+	}
+}
@@ -13,6 +13,7 @@ import (
 
 const (
 	benchMetadataKeys     = 5
+	benchMetadataManyKeys = 10
 	benchMetadataIntValue = 42
 	benchAddErrorCount    = 10
 	benchBreakerFailLimit = 5
@@ -178,6 +179,36 @@ func BenchmarkFormatting(b *testing.B) {
 	})
 }
 
+// BenchmarkJSONMarshaler compares the goccy and stdlib JSON encoders on the
+// same error.
+func BenchmarkJSONMarshaler(b *testing.B) {
+	err := ewrap.New("test error",
+		ewrap.WithContext(context.Background(), ewrap.ErrorTypeDatabase, ewrap.SeverityError)).
+		WithMetadata("key1", "value1").
+		WithMetadata("key2", benchMetadataIntValue)
+
+	b.Cleanup(func() { ewrap.SetJSONMarshaler(nil) })
+
+	marshalers := []struct {
+		name      string
+		marshaler ewrap.JSONMarshaler
+	}{
+		{"Goccy", ewrap.GoccyJSON{}},
+		{"Stdlib", ewrap.StdlibJSON{}},
+	}
+
+	for _, m := range marshalers {
+		b.Run(m.name, func(b *testing.B) {
+			ewrap.SetJSONMarshaler(m.marshaler)
+			b.ReportAllocs()
+
+			for b.Loop() {
+				_, _ = err.ToJSON()
+			}
+		})
+	}
+}
+
 // BenchmarkCircuitBreaker measures the performance of circuit breaker operations.
 func BenchmarkCircuitBreaker(b *testing.B) {
 	b.Run("RecordFailure", benchBreakerRecordFailure)
@@ -225,6 +256,20 @@ func BenchmarkMetadataOperations(b *testing.B) {
 		}
 	})
 
+	b.Run("AddMetadataMap", func(b *testing.B) {
+		entries := make(map[string]any, benchMetadataKeys)
+		for j := range benchMetadataKeys {
+			entries[fmt.Sprintf("key%d", j)] = j
+		}
+
+		b.ReportAllocs()
+		b.ResetTimer()
+
+		for range b.N {
+			_ = ewrap.New("test error").WithMetadataMap(entries)
+		}
+	})
+
 	b.Run("GetMetadata", func(b *testing.B) {
 		err := ewrap.New("test error")
 		for i := range benchMetadataKeys {
@@ -240,6 +285,37 @@ func BenchmarkMetadataOperations(b *testing.B) {
 	})
 }
 
+// BenchmarkMetadataCapacity compares growing the metadata map key by key
+// with pre-sizing it via WithInitialMetadataCapacity.
+func BenchmarkMetadataCapacity(b *testing.B) {
+	keys := make([]string, benchMetadataManyKeys)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key%d", i)
+	}
+
+	fill := func(err *ewrap.Error) {
+		for i, key := range keys {
+			_ = err.WithMetadata(key, i)
+		}
+	}
+
+	b.Run("Default", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for b.Loop() {
+			fill(ewrap.New("test error"))
+		}
+	})
+
+	b.Run("PreSized", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for b.Loop() {
+			fill(ewrap.New("test error", ewrap.WithInitialMetadataCapacity(benchMetadataManyKeys)))
+		}
+	})
+}
+
 // BenchmarkStackTrace measures the performance of stack trace operations.
 func BenchmarkStackTrace(b *testing.B) {
 	err := ewrap.New("test error")
@@ -0,0 +1,115 @@
+package ewrap
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Branch markers for Tree. They're plain ASCII so the output survives any
+// terminal or log sink.
+const (
+	treeBranch     = "+- "
+	treeLastBranch = "`- "
+	treeIndent     = "|  "
+	treeLastIndent = "   "
+)
+
+// Tree renders the error chain as an indented ASCII tree, one message per
+// line. A single-cause chain is a straight line of nested "`- " entries;
+// errors with several causes (Newf with more than one %w, errors.Join, an
+// ErrorGroup) fan out into one branch per cause:
+//
+//	loading dashboard
+//	`- 2 errors
+//	   +- querying orders
+//	   |  `- connection refused
+//	   `- querying invoices
+//
+// Each *Error node shows its own message rather than the full Error() text.
+// Rendering stops after maxUnwrapLayers nodes, so a cyclic chain can't loop.
+func (e *Error) Tree() string {
+	if e == nil {
+		return ""
+	}
+
+	var builder strings.Builder
+
+	budget := maxUnwrapLayers
+	writeTreeNode(&builder, e, "", "", &budget)
+
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+// writeTreeNode writes err's line with the given prefix, then its causes
+// indented under childPrefix.
+func writeTreeNode(builder *strings.Builder, err error, prefix, childPrefix string, budget *int) {
+	if *budget <= 0 {
+		return
+	}
+
+	*budget--
+
+	children := treeChildren(err)
+
+	builder.WriteString(prefix)
+	builder.WriteString(treeLabel(err, len(children)))
+	builder.WriteByte('\n')
+
+	for i, child := range children {
+		if child == nil {
+			continue
+		}
+
+		if i == len(children)-1 {
+			writeTreeNode(builder, child, childPrefix+treeLastBranch, childPrefix+treeLastIndent, budget)
+		} else {
+			writeTreeNode(builder, child, childPrefix+treeBranch, childPrefix+treeIndent, budget)
+		}
+	}
+}
+
+// treeChildren returns the direct causes of err. A multi-error that Newf
+// built from several %w verbs is only a carrier for the operands, whose text
+// is already in the message, so its operands become the node's children.
+func treeChildren(err error) []error {
+	if e, ok := err.(*Error); ok {
+		if e.cause == nil {
+			return nil
+		}
+
+		if _, nested := e.cause.(*Error); !nested && e.fullMsg {
+			if multi, ok := e.cause.(interface{ Unwrap() []error }); ok {
+				return multi.Unwrap()
+			}
+		}
+
+		return []error{e.cause}
+	}
+
+	switch u := err.(type) {
+	case interface{ Unwrap() []error }:
+		return u.Unwrap()
+	case interface{ Unwrap() error }:
+		if cause := u.Unwrap(); cause != nil {
+			return []error{cause}
+		}
+	}
+
+	return nil
+}
+
+// treeLabel is the text shown for err's node. Multi-errors such as
+// errors.Join and ErrorGroup list their causes across several lines, which
+// the branches already show, so they're labelled with a count instead.
+func treeLabel(err error, children int) string {
+	if e, ok := err.(*Error); ok {
+		return e.msg
+	}
+
+	msg := err.Error()
+	if children > 1 && strings.Contains(msg, "\n") {
+		return fmt.Sprintf("%d errors", children)
+	}
+
+	return msg
+}
@@ -0,0 +1,66 @@
+package ewrap
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestTreeLinearChain(t *testing.T) {
+	t.Parallel()
+
+	err := Wrap(fmt.Errorf("dialing: %w", errRoot), msgWrapped)
+
+	want := "wrapped\n" +
+		"`- dialing: root\n" +
+		"   `- root"
+
+	if got := err.Tree(); got != want {
+		t.Errorf("Tree() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTreeMultiCause(t *testing.T) {
+	t.Parallel()
+
+	err := Newf("batch: %w, %w", Wrap(errRoot, msgFirst), errSecond)
+
+	want := "batch: first: root, second\n" +
+		"+- first\n" +
+		"|  `- root\n" +
+		"`- second"
+
+	if got := err.Tree(); got != want {
+		t.Errorf("Tree() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTreeJoinedAndGrouped(t *testing.T) {
+	t.Parallel()
+
+	group := NewErrorGroup()
+	group.Add(errFirst)
+	group.Add(errSecond)
+
+	err := Wrap(errors.Join(group, errRoot), msgWrapped)
+
+	want := "wrapped\n" +
+		"`- 2 errors\n" +
+		"   +- 2 errors\n" +
+		"   |  +- first\n" +
+		"   |  `- second\n" +
+		"   `- root"
+
+	if got := err.Tree(); got != want {
+		t.Errorf("Tree() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTreeNil(t *testing.T) {
+	t.Parallel()
+
+	var err *Error
+	if got := err.Tree(); got != "" {
+		t.Errorf("nil Tree() = %q, want empty", got)
+	}
+}
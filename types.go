@@ -1,5 +1,7 @@
 package ewrap
 
+import "errors"
+
 // Canonical string forms for ErrorType and Severity. These are the values
 // returned by String() and used in serialized payloads, so they're worth
 // pinning as named constants rather than free-floating literals.
@@ -101,7 +103,62 @@ func (s Severity) String() string {
 	}
 }
 
+// IsType reports whether the error's type matches t. The type is taken from
+// the first ErrorContext found walking the chain, so a type set on an inner
+// error is visible from its wrappers. Errors without a context report
+// ErrorTypeUnknown.
+func (e *Error) IsType(t ErrorType) bool {
+	if ctx := chainErrorContext(e); ctx != nil {
+		return ctx.Type == t
+	}
+
+	return t == ErrorTypeUnknown
+}
+
+// SeverityLevel returns the error's severity, taken from the first
+// ErrorContext found walking the chain. Errors without a context report
+// SeverityError.
+func (e *Error) SeverityLevel() Severity {
+	return severityOf(e)
+}
+
+// severityOf classifies err by the first ErrorContext in its chain,
+// defaulting to SeverityError when none is attached.
+func severityOf(err error) Severity {
+	if ctx := chainErrorContext(err); ctx != nil {
+		return ctx.Severity
+	}
+
+	return SeverityError
+}
+
+// typeOf classifies err by the first ErrorContext in its chain, defaulting
+// to ErrorTypeUnknown when none is attached.
+func typeOf(err error) ErrorType {
+	if ctx := chainErrorContext(err); ctx != nil {
+		return ctx.Type
+	}
+
+	return ErrorTypeUnknown
+}
+
+// chainErrorContext walks err's chain and returns the first ErrorContext
+// attached to an *Error, or nil.
+func chainErrorContext(err error) *ErrorContext {
+	for cur := err; cur != nil; cur = errors.Unwrap(cur) {
+		if e, ok := cur.(*Error); ok && e.errorContext != nil {
+			return e.errorContext
+		}
+	}
+
+	return nil
+}
+
 // RecoverySuggestion provides guidance on how to recover from an error.
+//
+// Message and Actions may contain {key} placeholders, which serialized
+// output fills in from the error's metadata, as in "retry after
+// {retry_after}s".
 type RecoverySuggestion struct {
 	// Message provides a human-readable explanation.
 	Message string `json:"message" yaml:"message"`
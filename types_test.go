@@ -1,6 +1,10 @@
 package ewrap
 
-import "testing"
+import (
+	"context"
+	"fmt"
+	"testing"
+)
 
 const (
 	invalidEnumValue       = 999
@@ -122,3 +126,60 @@ func TestRecoverySuggestion(t *testing.T) {
 		t.Errorf("RecoverySuggestion.Documentation = %v, want %v", rs.Documentation, "https://example.com/docs")
 	}
 }
+
+func TestErrorIsTypeAndSeverityLevel(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("typed error", func(t *testing.T) {
+		t.Parallel()
+
+		err := New(msgTest, WithContext(ctx, ErrorTypeDatabase, SeverityCritical))
+
+		if !err.IsType(ErrorTypeDatabase) {
+			t.Error("expected IsType(ErrorTypeDatabase) to be true")
+		}
+
+		if err.IsType(ErrorTypeNetwork) {
+			t.Error("expected IsType(ErrorTypeNetwork) to be false")
+		}
+
+		if got := err.SeverityLevel(); got != SeverityCritical {
+			t.Errorf("SeverityLevel = %v, want %v", got, SeverityCritical)
+		}
+	})
+
+	t.Run("wrapped typed error", func(t *testing.T) {
+		t.Parallel()
+
+		inner := New(msgTest, WithContext(ctx, ErrorTypeValidation, SeverityWarning))
+		outer := Wrap(fmt.Errorf("stdlib layer: %w", inner), msgWrapped)
+
+		if !outer.IsType(ErrorTypeValidation) {
+			t.Error("expected inner type to be visible from the wrapper")
+		}
+
+		if got := outer.SeverityLevel(); got != SeverityWarning {
+			t.Errorf("SeverityLevel = %v, want %v", got, SeverityWarning)
+		}
+	})
+
+	t.Run("context-less error", func(t *testing.T) {
+		t.Parallel()
+
+		err := Wrap(errPlain, msgWrapped)
+
+		if !err.IsType(ErrorTypeUnknown) {
+			t.Error("expected context-less error to be ErrorTypeUnknown")
+		}
+
+		if err.IsType(ErrorTypeInternal) {
+			t.Error("expected IsType(ErrorTypeInternal) to be false")
+		}
+
+		if got := err.SeverityLevel(); got != SeverityError {
+			t.Errorf("SeverityLevel = %v, want %v", got, SeverityError)
+		}
+	})
+}